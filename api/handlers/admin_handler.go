@@ -0,0 +1,351 @@
+// api/handlers/admin_handler.go
+
+// 운영용 관리자 API 핸들러
+
+package handlers
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seojoonrp/bapddang-server/api/services"
+	"github.com/seojoonrp/bapddang-server/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type AdminHandler struct {
+	statsService       services.StatsService
+	foodService        services.FoodService
+	foodAdminService   services.FoodAdminService
+	foodCatalogService services.FoodCatalogService
+	imageService       services.ImageService
+}
+
+func NewAdminHandler(statsService services.StatsService, foodService services.FoodService, foodAdminService services.FoodAdminService, foodCatalogService services.FoodCatalogService, imageService services.ImageService) *AdminHandler {
+	return &AdminHandler{
+		statsService:       statsService,
+		foodService:        foodService,
+		foodAdminService:   foodAdminService,
+		foodCatalogService: foodCatalogService,
+		imageService:       imageService,
+	}
+}
+
+func (h *AdminHandler) ReconcileFoodStats(ctx *gin.Context) {
+	dryRun := ctx.Query("dryRun") == "true"
+
+	report, err := h.statsService.ReconcileFoodStats(dryRun)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reconcile food stats"})
+		return
+	}
+
+	if report.Fixed {
+		if err := h.foodService.ReloadStandardFoodCache(); err != nil {
+			log.Printf("Failed to reload standard food cache after reconciliation: %v", err)
+		}
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// 이 서버 인스턴스의 음식 캐시 상태 (버전, 마지막 전체 동기화 이후 경과 시간, change stream 상태)
+func (h *AdminHandler) GetFoodCacheStatus(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, h.foodService.GetCacheStatus())
+}
+
+func (h *AdminHandler) UpdateStandardFood(ctx *gin.Context) {
+	foodID, err := primitive.ObjectIDFromHex(ctx.Param("foodID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid food ID"})
+		return
+	}
+
+	var input models.UpdateStandardFoodInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	food, err := h.foodAdminService.UpdateStandardFood(foodID, input)
+	if err != nil {
+		switch {
+		case err == mongo.ErrNoDocuments:
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Food not found"})
+		case errors.Is(err, services.ErrFoodAlreadyExists):
+			ctx.JSON(http.StatusConflict, gin.H{"error": "Food already exists"})
+		case errors.Is(err, services.ErrAliasInUse):
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case services.IsFoodValidationError(err):
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update food"})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, food)
+}
+
+func (h *AdminHandler) ArchiveStandardFood(ctx *gin.Context) {
+	h.setStandardFoodArchived(ctx, true)
+}
+
+func (h *AdminHandler) UnarchiveStandardFood(ctx *gin.Context) {
+	h.setStandardFoodArchived(ctx, false)
+}
+
+func (h *AdminHandler) setStandardFoodArchived(ctx *gin.Context, archived bool) {
+	foodID, err := primitive.ObjectIDFromHex(ctx.Param("foodID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid food ID"})
+		return
+	}
+
+	food, err := h.foodAdminService.SetStandardFoodArchived(foodID, archived)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Food not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update food"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, food)
+}
+
+func (h *AdminHandler) DeleteStandardFood(ctx *gin.Context) {
+	foodID, err := primitive.ObjectIDFromHex(ctx.Param("foodID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid food ID"})
+		return
+	}
+
+	result, err := h.foodAdminService.DeleteStandardFood(foodID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Food not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete food"})
+		return
+	}
+
+	h.imageService.DeleteImage(result.DeletedFood.ImageKey)
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// 본문에 CSV나 JSON을 그대로 보내거나, multipart의 "file" 필드로 파일을 올림
+// format 쿼리가 없으면 Content-Type이나 파일 확장자로 판단
+func (h *AdminHandler) ImportStandardFoods(ctx *gin.Context) {
+	dryRun := ctx.Query("dryRun") == "true"
+	format := ctx.Query("format")
+
+	var body io.Reader = ctx.Request.Body
+	if ctx.ContentType() == "multipart/form-data" {
+		fileHeader, err := ctx.FormFile("file")
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Catalog file is required"})
+			return
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read catalog file"})
+			return
+		}
+		defer file.Close()
+
+		body = file
+		if format == "" {
+			format = strings.TrimPrefix(strings.ToLower(filepath.Ext(fileHeader.Filename)), ".")
+		}
+	}
+	if format == "" {
+		format = services.FoodCatalogFormatJSON
+		if strings.Contains(ctx.ContentType(), "csv") {
+			format = services.FoodCatalogFormatCSV
+		}
+	}
+
+	catalog, err := services.ParseFoodCatalog(format, body)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.foodCatalogService.ImportStandardFoods(catalog, dryRun)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import foods"})
+		return
+	}
+
+	if len(report.Errors) > 0 {
+		ctx.JSON(http.StatusUnprocessableEntity, report)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+func (h *AdminHandler) ExportStandardFoods(ctx *gin.Context) {
+	format := ctx.DefaultQuery("format", services.FoodCatalogFormatJSON)
+	if format != services.FoodCatalogFormatJSON && format != services.FoodCatalogFormatCSV {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "unsupported catalog format"})
+		return
+	}
+
+	rows, err := h.foodCatalogService.ExportStandardFoods()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export foods"})
+		return
+	}
+
+	contentType := "application/json; charset=utf-8"
+	if format == services.FoodCatalogFormatCSV {
+		contentType = "text/csv; charset=utf-8"
+	}
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("Content-Disposition", "attachment; filename=standard_foods."+format)
+	ctx.Status(http.StatusOK)
+
+	if err := services.WriteFoodCatalog(format, ctx.Writer, rows); err != nil {
+		log.Printf("Failed to write food catalog export: %v", err)
+	}
+}
+
+func (h *AdminHandler) ListPopularCustomFoods(ctx *gin.Context) {
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "100"))
+	if err != nil || limit < 1 || limit > 1000 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+	threshold, err := strconv.ParseFloat(ctx.DefaultQuery("threshold", "0.85"), 64)
+	if err != nil || threshold <= 0 || threshold > 1 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid threshold"})
+		return
+	}
+
+	clusters, err := h.foodAdminService.ListPopularCustomFoods(limit, threshold)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list custom foods"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"clusters": clusters})
+}
+
+func (h *AdminHandler) PromoteCustomFood(ctx *gin.Context) {
+	foodID, err := primitive.ObjectIDFromHex(ctx.Param("foodID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid food ID"})
+		return
+	}
+
+	var input models.PromoteCustomFoodInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	result, err := h.foodAdminService.PromoteCustomFood(foodID, input)
+	if err != nil {
+		switch {
+		case err == mongo.ErrNoDocuments:
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Food not found"})
+		case errors.Is(err, services.ErrFoodAlreadyExists):
+			ctx.JSON(http.StatusConflict, gin.H{"error": "Food already exists"})
+		case errors.Is(err, services.ErrAliasInUse):
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case services.IsFoodValidationError(err):
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to promote food"})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, result)
+}
+
+func (h *AdminHandler) MergeFoods(ctx *gin.Context) {
+	var input models.MergeFoodsInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	result, err := h.foodAdminService.MergeFoods(input)
+	if err != nil {
+		switch {
+		case err == mongo.ErrNoDocuments:
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Food not found"})
+		case errors.Is(err, services.ErrInvalidMergeRequest):
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to merge foods"})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (h *AdminHandler) AddStandardFoodAliases(ctx *gin.Context) {
+	foodID, err := primitive.ObjectIDFromHex(ctx.Param("foodID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid food ID"})
+		return
+	}
+
+	var input models.StandardFoodAliasesInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	food, err := h.foodAdminService.AddStandardFoodAliases(foodID, input.Aliases)
+	if err != nil {
+		switch {
+		case err == mongo.ErrNoDocuments:
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Food not found"})
+		case errors.Is(err, services.ErrAliasInUse):
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrInvalidAlias):
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add aliases"})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, food)
+}
+
+func (h *AdminHandler) RemoveStandardFoodAlias(ctx *gin.Context) {
+	foodID, err := primitive.ObjectIDFromHex(ctx.Param("foodID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid food ID"})
+		return
+	}
+
+	food, err := h.foodAdminService.RemoveStandardFoodAlias(foodID, ctx.Param("alias"))
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Food not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove alias"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, food)
+}
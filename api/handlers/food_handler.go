@@ -3,22 +3,29 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/seojoonrp/bapddang-server/api/services"
 	"github.com/seojoonrp/bapddang-server/models"
+	"github.com/seojoonrp/bapddang-server/utils"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type FoodHandler struct {
-	foodService services.FoodService
+	foodService  services.FoodService
+	feedService  services.FeedService
+	imageService services.ImageService
 }
 
-func NewFoodHandler(foodService services.FoodService) *FoodHandler {
+func NewFoodHandler(foodService services.FoodService, feedService services.FeedService, imageService services.ImageService) *FoodHandler {
 	return &FoodHandler{
-		foodService: foodService,
+		foodService:  foodService,
+		feedService:  feedService,
+		imageService: imageService,
 	}
 }
 
@@ -48,16 +55,74 @@ func (h *FoodHandler) CreateStandardFood(ctx *gin.Context) {
 
 	newFood, err := h.foodService.CreateStandardFood(input)
 	if err != nil {
-		if err.Error() == "food already exists" {
+		switch {
+		case errors.Is(err, services.ErrFoodAlreadyExists):
 			ctx.JSON(http.StatusConflict, gin.H{"error": "Food already exists"})
+		case errors.Is(err, services.ErrAliasInUse):
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case services.IsFoodValidationError(err):
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create food"})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, newFood)
+}
+
+func (h *FoodHandler) UploadStandardFoodImage(ctx *gin.Context) {
+	foodIDStr := ctx.Param("foodID")
+
+	food, err := h.foodService.GetStandardFoodByID(foodIDStr)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Food not found"})
 			return
 		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid food ID"})
+		return
+	}
 
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create food"})
+	fileHeader, err := ctx.FormFile("image")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Image file is required"})
+		return
+	}
+	if fileHeader.Size > utils.MaxImageUploadSize {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "image too large"})
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, newFood)
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read image file"})
+		return
+	}
+	defer file.Close()
+
+	image, err := h.imageService.StoreImage(h.imageService.NewImageKey("foods/"+food.ID.Hex()+"/"), file)
+	if err != nil {
+		if utils.IsImageValidationError(err) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store image"})
+		return
+	}
+
+	if err := h.foodService.UpdateStandardFoodImage(food.ID, image); err != nil {
+		h.imageService.DeleteImage(image.Key)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update food image"})
+		return
+	}
+	h.imageService.DeleteImage(food.ImageKey)
+
+	food.ImageURL = image.URL
+	food.ImageKey = image.Key
+	food.ImageVariants = &image.Variants
+
+	ctx.JSON(http.StatusOK, food)
 }
 
 func (h *FoodHandler) FindOrCreateCustomFood(ctx *gin.Context) {
@@ -83,6 +148,9 @@ func (h *FoodHandler) FindOrCreateCustomFood(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, customFood)
 }
 
+// 요청마다 카탈로그 전체를 점수 매겨 내려보내지 않도록 검색/리뷰 기록 limit과 같은 상한을 둠
+const maxMainFeedCount = 50
+
 func (h *FoodHandler) GetMainFeedFoods(ctx *gin.Context) {
 	foodType := ctx.Query("type")
 	if foodType != "meal" && foodType != "dessert" {
@@ -99,18 +167,61 @@ func (h *FoodHandler) GetMainFeedFoods(ctx *gin.Context) {
 		foodCountStr = "10"
 	}
 	foodCount, err := strconv.Atoi(foodCountStr)
-	if err != nil {
+	if err != nil || foodCount < 1 || foodCount > maxMainFeedCount {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid count"})
 		return
 	}
 
-	selectedFoods, err := h.foodService.GetMainFeedFoods(foodType, speed, foodCount)
+	// seed를 주면 같은 순서를 다시 받을 수 있고, 다음 페이지는 이전 응답의 nextCursor로 요청함
+	query := models.MainFeedQuery{Type: foodType, Speed: speed, Count: foodCount, Cursor: ctx.Query("cursor")}
+	if seedStr := ctx.Query("seed"); seedStr != "" {
+		seed, err := strconv.ParseInt(seedStr, 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid seed"})
+			return
+		}
+		query.Seed = &seed
+	}
+
+	// mode=meal-time이면 끼니에 맞는 음식을 올려줌. mealTime을 주지 않으면 현재 한국 시간으로 끼니를 정함
+	if mode := ctx.Query("mode"); mode != "" {
+		if mode != "meal-time" {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mode"})
+			return
+		}
+		query.MealTimeMode = true
+		if mealTimeStr := ctx.Query("mealTime"); mealTimeStr != "" {
+			query.MealTime = utils.NormalizeMealTime(mealTimeStr)
+			if query.MealTime == "" {
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid meal time"})
+				return
+			}
+		}
+	}
+
+	// 로그인하지 않아도 볼 수 있으며, 로그인한 경우에만 개인화됨
+	var user *models.User
+	if userCtx, exists := ctx.Get("currentUser"); exists {
+		currentUser := userCtx.(models.User)
+		user = &currentUser
+	}
+
+	page, err := h.feedService.GetMainFeed(query, user)
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidFeedCursor) || errors.Is(err, services.ErrFeedCursorMismatch) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get main feed foods"})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, selectedFoods)
+	// seed/cursor 없이 부르는 예전 클라이언트는 음식 배열만 받음 (페이지를 이어받으려면 seed를 줘야 함)
+	if query.Seed == nil && query.Cursor == "" {
+		ctx.JSON(http.StatusOK, page.Foods)
+		return
+	}
+	ctx.JSON(http.StatusOK, page)
 }
 
 func (h *FoodHandler) ValidateFoods(ctx *gin.Context) {
@@ -135,3 +246,45 @@ func (h *FoodHandler) ValidateFoods(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, gin.H{"results": results})
 }
+
+func (h *FoodHandler) SearchFoods(ctx *gin.Context) {
+	query := models.FoodSearchQuery{
+		Query:    strings.TrimSpace(ctx.Query("q")),
+		Type:     ctx.Query("type"),
+		Speed:    ctx.Query("speed"),
+		Category: ctx.Query("category"),
+	}
+	if query.Query == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Query is required"})
+		return
+	}
+	if query.Type != "" && query.Type != "meal" && query.Type != "dessert" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid food type"})
+		return
+	}
+	if query.Speed != "" && query.Speed != "fast" && query.Speed != "slow" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid speed"})
+		return
+	}
+
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 50 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+	offset, err := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset"})
+		return
+	}
+	query.Limit = limit
+	query.Offset = offset
+
+	page, err := h.foodService.SearchFoods(query)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search foods"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
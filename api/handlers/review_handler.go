@@ -5,24 +5,27 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/seojoonrp/bapddang-server/api/services"
 	"github.com/seojoonrp/bapddang-server/models"
+	"github.com/seojoonrp/bapddang-server/utils"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type ReviewHandler struct {
 	reviewService services.ReviewService
-	foodService   services.FoodService
 }
 
-func NewReviewHandler(reviewService services.ReviewService, foodService services.FoodService) *ReviewHandler {
+func NewReviewHandler(reviewService services.ReviewService) *ReviewHandler {
 	return &ReviewHandler{
 		reviewService: reviewService,
-		foodService:   foodService,
 	}
 }
 
@@ -32,6 +35,9 @@ func (h *ReviewHandler) CreateReview(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review request format"})
 		return
 	}
+	if !checkLegacyImageURL(ctx, input) {
+		return
+	}
 
 	userCtx, exists := ctx.Get("currentUser")
 	if !exists {
@@ -42,20 +48,14 @@ func (h *ReviewHandler) CreateReview(ctx *gin.Context) {
 
 	newReview, err := h.reviewService.CreateReview(input, user)
 	if err != nil {
+		if isImageKeyError(err) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to create review"})
 		return
 	}
 
-	standardFoods := make([]primitive.ObjectID, 0)
-	for _, foodItem := range newReview.Foods {
-		if foodItem.FoodType == "standard" {
-			standardFoods = append(standardFoods, foodItem.FoodID)
-		}
-	}
-	if len(standardFoods) > 0 {
-		go h.foodService.UpdateCreatedReviewStats(standardFoods, input.Rating)
-	}
-
 	ctx.JSON(http.StatusCreated, newReview)
 }
 
@@ -65,6 +65,9 @@ func (h *ReviewHandler) UpdateReview(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review request format"})
 		return
 	}
+	if !checkLegacyImageURL(ctx, input) {
+		return
+	}
 
 	userCtx, exists := ctx.Get("currentUser")
 	if !exists {
@@ -80,23 +83,49 @@ func (h *ReviewHandler) UpdateReview(ctx *gin.Context) {
 		return
 	}
 
-	updatedReview, oldRating, err := h.reviewService.UpdateReview(reviewID, input, user)
+	updatedReview, err := h.reviewService.UpdateReview(reviewID, input, user)
 	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Review not found"})
+			return
+		}
+		if isImageKeyError(err) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update review"})
 		return
 	}
 
-	standardFoods := make([]primitive.ObjectID, 0)
-	for _, foodItem := range updatedReview.Foods {
-		if foodItem.FoodType == "standard" {
-			standardFoods = append(standardFoods, foodItem.FoodID)
-		}
+	ctx.JSON(http.StatusOK, updatedReview)
+}
+
+func (h *ReviewHandler) DeleteReview(ctx *gin.Context) {
+	userCtx, exists := ctx.Get("currentUser")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
 	}
-	if len(standardFoods) > 0 && oldRating != input.Rating {
-		go h.foodService.UpdateModifiedReviewStats(standardFoods, oldRating, input.Rating)
+	user := userCtx.(models.User)
+
+	reviewIDHex := ctx.Param("reviewID")
+	reviewID, err := primitive.ObjectIDFromHex(reviewIDHex)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review ID format"})
+		return
 	}
 
-	ctx.JSON(http.StatusOK, updatedReview)
+	_, err = h.reviewService.DeleteReview(reviewID, user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Review not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete review"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Review deleted successfully"})
 }
 
 func (h *ReviewHandler) GetMyReviewsByDay(ctx *gin.Context) {
@@ -127,3 +156,135 @@ func (h *ReviewHandler) GetMyReviewsByDay(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, reviews)
 }
+
+func (h *ReviewHandler) GetMyReviewHistory(ctx *gin.Context) {
+	userCtx, exists := ctx.Get("currentUser")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+	user := userCtx.(models.User)
+
+	query, err := parseReviewHistoryQuery(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	query.UserID = user.ID
+
+	page, err := h.reviewService.GetMyReviewHistory(query)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to fetch reviews"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+// 이미지는 업로드 후 imageKey로만 받음. 수정 화면에서 기존 리뷰를 그대로 보내는 경우처럼 imageKey와 함께 오면 imageUrl은 무시함
+func checkLegacyImageURL(ctx *gin.Context, input models.ReviewInput) bool {
+	if input.ImageURL != "" && input.ImageKey == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "imageUrl is no longer supported, upload the image and send imageKey"})
+		return false
+	}
+	return true
+}
+
+func isImageKeyError(err error) bool {
+	return errors.Is(err, services.ErrInvalidImageKey) || errors.Is(err, services.ErrImageNotUploaded) ||
+		errors.Is(err, services.ErrImageAlreadyAttached) || utils.IsImageValidationError(err)
+}
+
+const (
+	defaultReviewHistoryLimit = 20
+	maxReviewHistoryLimit     = 50
+)
+
+func parseReviewHistoryQuery(ctx *gin.Context) (models.ReviewHistoryQuery, error) {
+	query := models.ReviewHistoryQuery{Limit: defaultReviewHistoryLimit}
+
+	parseInt := func(key string) (*int, error) {
+		str := ctx.Query(key)
+		if str == "" {
+			return nil, nil
+		}
+		value, err := strconv.Atoi(str)
+		if err != nil {
+			return nil, errors.New("Invalid " + key + " query parameter")
+		}
+		return &value, nil
+	}
+	parseTime := func(key string) (*time.Time, error) {
+		str := ctx.Query(key)
+		if str == "" {
+			return nil, nil
+		}
+		value, err := time.Parse(time.RFC3339, str)
+		if err != nil {
+			return nil, errors.New("Invalid " + key + " query parameter")
+		}
+		return &value, nil
+	}
+	parseObjectID := func(key string) (*primitive.ObjectID, error) {
+		str := ctx.Query(key)
+		if str == "" {
+			return nil, nil
+		}
+		value, err := primitive.ObjectIDFromHex(str)
+		if err != nil {
+			return nil, errors.New("Invalid " + key + " query parameter")
+		}
+		return &value, nil
+	}
+
+	var err error
+	if query.DayFrom, err = parseInt("dayFrom"); err != nil {
+		return query, err
+	}
+	if query.DayTo, err = parseInt("dayTo"); err != nil {
+		return query, err
+	}
+	if query.CreatedFrom, err = parseTime("from"); err != nil {
+		return query, err
+	}
+	if query.CreatedTo, err = parseTime("to"); err != nil {
+		return query, err
+	}
+	if query.FoodID, err = parseObjectID("foodId"); err != nil {
+		return query, err
+	}
+	if query.Cursor, err = parseObjectID("cursor"); err != nil {
+		return query, err
+	}
+
+	minRating, err := parseInt("minRating")
+	if err != nil {
+		return query, err
+	}
+	if minRating != nil {
+		query.MinRating = *minRating
+	}
+
+	limit, err := parseInt("limit")
+	if err != nil {
+		return query, err
+	}
+	if limit != nil {
+		if *limit <= 0 || *limit > maxReviewHistoryLimit {
+			return query, errors.New("Invalid limit query parameter")
+		}
+		query.Limit = *limit
+	}
+
+	query.MealTime = utils.CanonicalMealTime(ctx.Query("mealTime"))
+	query.Speed = ctx.Query("speed")
+	if tags := ctx.Query("tags"); tags != "" {
+		for _, tag := range strings.Split(tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				query.Tags = append(query.Tags, tag)
+			}
+		}
+	}
+
+	return query, nil
+}
@@ -0,0 +1,69 @@
+// api/handlers/storage_handler.go
+
+// 로컬 디스크 저장소를 사용할 때 presigned URL 업로드와 파일 제공을 처리
+
+package handlers
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seojoonrp/bapddang-server/storage"
+)
+
+const maxLocalUploadSize = 20 << 20
+
+type StorageHandler struct {
+	localStorage *storage.LocalStorage
+}
+
+func NewStorageHandler(localStorage *storage.LocalStorage) *StorageHandler {
+	return &StorageHandler{
+		localStorage: localStorage,
+	}
+}
+
+func (h *StorageHandler) UploadObject(ctx *gin.Context) {
+	key := strings.TrimPrefix(ctx.Param("key"), "/")
+	contentType := ctx.GetHeader("Content-Type")
+
+	err := h.localStorage.VerifyPresignedPut(key, contentType, ctx.Query("expires"), ctx.Query("signature"))
+	if err != nil {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	body := http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxLocalUploadSize)
+	if err := h.localStorage.Put(key, body, contentType); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Failed to store object"})
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (h *StorageHandler) ServeObject(ctx *gin.Context) {
+	key := strings.TrimPrefix(ctx.Param("key"), "/")
+
+	object, err := h.localStorage.Get(key)
+	if err != nil {
+		if err == storage.ErrObjectNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Object not found"})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid object key"})
+		return
+	}
+	defer object.Close()
+
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		ctx.Header("Content-Type", contentType)
+	}
+	ctx.Header("Cache-Control", "public, max-age=86400")
+	ctx.Status(http.StatusOK)
+	io.Copy(ctx.Writer, object)
+}
@@ -0,0 +1,40 @@
+// api/handlers/upload_handler.go
+
+// 이미지 업로드용 presigned URL 발급 API 핸들러
+
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seojoonrp/bapddang-server/api/services"
+	"github.com/seojoonrp/bapddang-server/models"
+)
+
+type UploadHandler struct {
+	uploadService services.UploadService
+}
+
+func NewUploadHandler(uploadService services.UploadService) *UploadHandler {
+	return &UploadHandler{
+		uploadService: uploadService,
+	}
+}
+
+func (h *UploadHandler) CreateReviewImageUpload(ctx *gin.Context) {
+	userCtx, exists := ctx.Get("currentUser")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+	user := userCtx.(models.User)
+
+	upload, err := h.uploadService.CreateReviewImageUpload(user.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create upload URL"})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, upload)
+}
@@ -186,8 +186,6 @@ func (h *UserHandler) LikeFood(ctx *gin.Context) {
 	}
 
 	if wasAdded {
-		go h.foodService.UpdateLikeStats(foodID, 1)
-
 		ctx.JSON(http.StatusOK, gin.H{"message": "Food liked successfully"})
 	} else {
 		ctx.JSON(http.StatusOK, gin.H{"message": "Food is already liked"})
@@ -216,8 +214,6 @@ func (h *UserHandler) UnlikeFood(ctx *gin.Context) {
 	}
 
 	if wasRemoved {
-		go h.foodService.UpdateLikeStats(foodID, -1)
-
 		ctx.JSON(http.StatusOK, gin.H{"message": "Food unliked successfully"})
 	} else {
 		ctx.JSON(http.StatusOK, gin.H{"message": "Food was not liked"})
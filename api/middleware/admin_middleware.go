@@ -0,0 +1,28 @@
+// middleware/admin_middleware.go
+// 관리자 권한 확인 미들웨어. AuthMiddleware 뒤에 사용해야 함
+
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/seojoonrp/bapddang-server/models"
+)
+
+func AdminMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userCtx, exists := ctx.Get("currentUser")
+		if !exists {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+			return
+		}
+
+		if userCtx.(models.User).Role != models.RoleAdmin {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Admin permission required"})
+			return
+		}
+
+		ctx.Next()
+	}
+}
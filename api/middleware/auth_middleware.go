@@ -5,6 +5,7 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -15,22 +16,12 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/seojoonrp/bapddang-server/config"
 	"github.com/seojoonrp/bapddang-server/models"
+	"github.com/seojoonrp/bapddang-server/utils"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-var seoulLoc *time.Location
-
-func init() {
-	var err error
-	seoulLoc, err = time.LoadLocation("Asia/Seoul")
-	if err != nil {
-		log.Printf("WARNING: Failed to load Asia/Seoul locatio, using UTC: %v", err)
-		seoulLoc = time.UTC
-	}
-}
-
 func AuthMiddleware(userCollection *mongo.Collection) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authHeader := ctx.GetHeader("Authorization")
@@ -39,59 +30,82 @@ func AuthMiddleware(userCollection *mongo.Collection) gin.HandlerFunc {
 			return
 		}
 
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header format not matched."})
+		user, err := authenticate(userCollection, authHeader)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 			return
 		}
-		tokenString := parts[1]
 
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		ctx.Set("currentUser", user)
+		ctx.Next()
+	}
+}
+
+// 로그인하지 않아도 되는 API용. 토큰이 유효하면 currentUser를 설정하고,
+// 헤더가 없거나 토큰이 잘못/만료되었으면 currentUser 없이 익명으로 그대로 진행함
+// (로그아웃한 앱이 예전 토큰을 들고 있어도 익명 응답을 받을 수 있도록)
+func OptionalAuthMiddleware(userCollection *mongo.Collection) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		authHeader := ctx.GetHeader("Authorization")
+		if authHeader != "" {
+			if user, err := authenticate(userCollection, authHeader); err == nil {
+				ctx.Set("currentUser", user)
 			}
-			return []byte(config.AppConfig.JWTSecret), nil
-		})
+		}
 
-		if err != nil || !token.Valid {
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
-			return
+		ctx.Next()
+	}
+}
+
+// 토큰을 검증해 유저를 찾음. 에러 메시지는 그대로 401 응답에 쓰임
+func authenticate(userCollection *mongo.Collection, authHeader string) (models.User, error) {
+	var user models.User
+
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return user, errors.New("Authorization header format not matched.")
+	}
+	tokenString := parts[1]
+
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
+		return []byte(config.AppConfig.JWTSecret), nil
+	})
 
-		if claims, ok := token.Claims.(jwt.MapClaims); ok {
-			userIDHex, ok := claims["sub"].(string)
-			if !ok {
-				ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
-				return
-			}
+	if err != nil || !token.Valid {
+		return user, errors.New("Invalid token")
+	}
 
-			userID, err := primitive.ObjectIDFromHex(userIDHex)
-			if err != nil {
-				ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid user ID in token"})
-				return
-			}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return user, errors.New("Invalid token claims")
+	}
 
-			var user models.User
-			err = userCollection.FindOne(context.TODO(), primitive.M{"_id": userID}).Decode(&user)
-			if err != nil {
-				ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
-				return
-			}
+	userIDHex, ok := claims["sub"].(string)
+	if !ok {
+		return user, errors.New("Invalid token claims")
+	}
 
-			calculatedDay := calculateCurrentDay(user.CreatedAt)
+	userID, err := primitive.ObjectIDFromHex(userIDHex)
+	if err != nil {
+		return user, errors.New("Invalid user ID in token")
+	}
 
-			if user.Day < calculatedDay {
-				user.Day = calculatedDay
-				go updateUserDay(user.ID, calculatedDay, userCollection)
-			}
+	err = userCollection.FindOne(context.TODO(), primitive.M{"_id": userID}).Decode(&user)
+	if err != nil {
+		return user, errors.New("User not found")
+	}
 
-			ctx.Set("currentUser", user)
+	calculatedDay := calculateCurrentDay(user.CreatedAt)
 
-			ctx.Next()
-		} else {
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
-		}
+	if user.Day < calculatedDay {
+		user.Day = calculatedDay
+		go updateUserDay(user.ID, calculatedDay, userCollection)
 	}
+
+	return user, nil
 }
 
 func updateUserDay(userID primitive.ObjectID, newDay int, userCollection *mongo.Collection) {
@@ -108,11 +122,11 @@ func updateUserDay(userID primitive.ObjectID, newDay int, userCollection *mongo.
 }
 
 func calculateCurrentDay(createdAt time.Time) int {
-	nowKST := time.Now().In(seoulLoc)
-	createdAtKST := createdAt.In(seoulLoc)
+	nowKST := time.Now().In(utils.SeoulLoc)
+	createdAtKST := createdAt.In(utils.SeoulLoc)
 
-	endDate := time.Date(nowKST.Year(), nowKST.Month(), nowKST.Day(), 0, 0, 0, 0, seoulLoc)
-	startDate := time.Date(createdAtKST.Year(), createdAtKST.Month(), createdAtKST.Day(), 0, 0, 0, 0, seoulLoc)
+	endDate := time.Date(nowKST.Year(), nowKST.Month(), nowKST.Day(), 0, 0, 0, 0, utils.SeoulLoc)
+	startDate := time.Date(createdAtKST.Year(), createdAtKST.Month(), createdAtKST.Day(), 0, 0, 0, 0, utils.SeoulLoc)
 
 	daysPassed := int(endDate.Sub(startDate).Hours() / 24)
 	return daysPassed + 1
@@ -0,0 +1,60 @@
+// api/repositories/food_redirect_repository.go
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/seojoonrp/bapddang-server/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type FoodRedirectRepository interface {
+	FindByFromID(fromID primitive.ObjectID) (*models.FoodRedirect, error)
+	Save(ctx context.Context, redirects []models.FoodRedirect) error
+	Retarget(ctx context.Context, oldTargetIDs []primitive.ObjectID, toID primitive.ObjectID, toType string) error
+}
+
+type foodRedirectRepository struct {
+	collection *mongo.Collection
+}
+
+func NewFoodRedirectRepository(coll *mongo.Collection) FoodRedirectRepository {
+	return &foodRedirectRepository{collection: coll}
+}
+
+func (r *foodRedirectRepository) FindByFromID(fromID primitive.ObjectID) (*models.FoodRedirect, error) {
+	var redirect models.FoodRedirect
+	err := r.collection.FindOne(context.TODO(), bson.M{"_id": fromID}).Decode(&redirect)
+	if err != nil {
+		return nil, err
+	}
+	return &redirect, nil
+}
+
+func (r *foodRedirectRepository) Save(ctx context.Context, redirects []models.FoodRedirect) error {
+	writes := make([]mongo.WriteModel, 0, len(redirects))
+	for _, redirect := range redirects {
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": redirect.FromID}).
+			SetReplacement(redirect).
+			SetUpsert(true))
+	}
+	if len(writes) == 0 {
+		return nil
+	}
+
+	_, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// 이전 병합에서 victim을 가리키던 기록을 새 대상으로 옮겨, 리다이렉트가 항상 한 단계로 끝나도록 함
+func (r *foodRedirectRepository) Retarget(ctx context.Context, oldTargetIDs []primitive.ObjectID, toID primitive.ObjectID, toType string) error {
+	filter := bson.M{"to_id": bson.M{"$in": oldTargetIDs}}
+	update := bson.M{"$set": bson.M{"to_id": toID, "to_type": toType}}
+	_, err := r.collection.UpdateMany(ctx, filter, update)
+	return err
+}
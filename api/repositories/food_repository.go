@@ -4,28 +4,51 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/seojoonrp/bapddang-server/models"
+	"github.com/seojoonrp/bapddang-server/utils"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type FoodRepository interface {
 	FindStandardFoodByID(id primitive.ObjectID) (*models.StandardFood, error)
 	FindStandardFoodByName(name string) (*models.StandardFood, error)
+	FindStandardFoodByNameOrAlias(name string) (*models.StandardFood, error)
 	FindCustomFoodByName(name string) (*models.CustomFood, error)
+	FindCustomFoodByID(id primitive.ObjectID) (*models.CustomFood, error)
+	FindCustomFoodsByUserCount(limit int) ([]models.CustomFoodRanking, error)
 	GetAllStandardFoods() ([]*models.StandardFood, error)
 	GetAllCustomFoods() ([]*models.CustomFood, error)
-
-	SaveCustomFood(food *models.CustomFood) error
-	SaveStandardFood(food *models.StandardFood) error
-
-	AddUserToCustomFood(foodID, userID primitive.ObjectID) error
-	UpdateCreatedReviewStats(foodID []primitive.ObjectID, rating int) error
-	UpdateModifiedReviewStats(foodID []primitive.ObjectID, oldRating, newRating int) error
-	IncrementLikeCount(foodID primitive.ObjectID) error
-	DecrementLikeCount(foodID primitive.ObjectID) error
+	FindDuplicateCustomFoods() ([][]models.CustomFood, error)
+
+	SaveStandardFood(ctx context.Context, food *models.StandardFood) error
+
+	UpdateStandardFoodImage(foodID primitive.ObjectID, image *models.StoredImage) error
+	UpdateStandardFood(foodID primitive.ObjectID, input models.UpdateStandardFoodInput) (*models.StandardFood, error)
+	AddStandardFoodAliases(foodID primitive.ObjectID, aliases []string) error
+	RemoveStandardFoodAlias(foodID primitive.ObjectID, alias string) error
+	UpsertStandardFoods(foods []*models.StandardFood) error
+	SetStandardFoodArchived(foodID primitive.ObjectID, archived bool) error
+	DeleteStandardFood(ctx context.Context, foodID primitive.ObjectID) (*models.StandardFood, error)
+	DeleteCustomFood(ctx context.Context, foodID primitive.ObjectID) error
+	AddUsersToCustomFood(ctx context.Context, foodID primitive.ObjectID, userIDs []primitive.ObjectID) error
+	FindOrCreateCustomFood(ctx context.Context, name string, userIDs []primitive.ObjectID) (*models.CustomFood, bool, error)
+	UpdateReviewStats(ctx context.Context, diffs []models.ReviewStatsDiff) error
+	IncrementLikeCount(ctx context.Context, foodID primitive.ObjectID) error
+	DecrementLikeCount(ctx context.Context, foodID primitive.ObjectID) error
+	SetFoodStats(ctx context.Context, stats models.FoodStats) error
+	CorrectFoodStats(drifts []models.FoodStatsDrift) error
+
+	EnsureIndexes() error
+	BackfillCustomFoodNormalizedNames() error
+	HasCustomFoodNameIndex() (bool, error)
+
+	WatchStandardFoods(ctx context.Context, resumeToken bson.Raw) (*mongo.ChangeStream, error)
+	WatchCustomFoods(ctx context.Context, resumeToken bson.Raw) (*mongo.ChangeStream, error)
 }
 
 type foodRepository struct {
@@ -58,15 +81,62 @@ func (r *foodRepository) FindStandardFoodByName(name string) (*models.StandardFo
 	return &food, nil
 }
 
+// 이름이나 별칭 중 하나라도 정확히 일치하는 음식을 찾음
+func (r *foodRepository) FindStandardFoodByNameOrAlias(name string) (*models.StandardFood, error) {
+	var food models.StandardFood
+	filter := bson.M{"$or": bson.A{bson.M{"name": name}, bson.M{"aliases": name}}}
+	err := r.standardFoodCollection.FindOne(context.TODO(), filter).Decode(&food)
+	if err != nil {
+		return nil, err
+	}
+	return &food, nil
+}
+
+// 띄어쓰기/대소문자만 다른 이름은 같은 custom 음식으로 봄
 func (r *foodRepository) FindCustomFoodByName(name string) (*models.CustomFood, error) {
 	var food models.CustomFood
-	err := r.customFoodCollection.FindOne(context.TODO(), bson.M{"name": name}).Decode(&food)
+	err := r.customFoodCollection.FindOne(context.TODO(), bson.M{"normalized_name": utils.NormalizeBasic(name)}).Decode(&food)
 	if err != nil {
 		return nil, err
 	}
 	return &food, nil
 }
 
+func (r *foodRepository) FindCustomFoodByID(id primitive.ObjectID) (*models.CustomFood, error) {
+	var food models.CustomFood
+	err := r.customFoodCollection.FindOne(context.TODO(), bson.M{"_id": id}).Decode(&food)
+	if err != nil {
+		return nil, err
+	}
+	return &food, nil
+}
+
+// 사용하는 유저 수가 많은 순서로 custom 음식을 가져옴
+func (r *foodRepository) FindCustomFoodsByUserCount(limit int) ([]models.CustomFoodRanking, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$project", Value: bson.M{
+			"name":       1,
+			"created_at": 1,
+			"user_count": bson.M{"$size": bson.M{"$ifNull": bson.A{"$using_user_ids", bson.A{}}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "user_count", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	cursor, err := r.customFoodCollection.Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var rankings []models.CustomFoodRanking
+	if err = cursor.All(context.TODO(), &rankings); err != nil {
+		return nil, err
+	}
+
+	return rankings, nil
+}
+
 func (r *foodRepository) GetAllStandardFoods() ([]*models.StandardFood, error) {
 	var foods []*models.StandardFood
 
@@ -101,75 +171,349 @@ func (r *foodRepository) GetAllCustomFoods() ([]*models.CustomFood, error) {
 	return foods, nil
 }
 
-func (r *foodRepository) SaveStandardFood(food *models.StandardFood) error {
-	_, err := r.standardFoodCollection.InsertOne(context.TODO(), food)
+func (r *foodRepository) SaveStandardFood(ctx context.Context, food *models.StandardFood) error {
+	_, err := r.standardFoodCollection.InsertOne(ctx, food)
+	return err
+}
+
+func (r *foodRepository) UpdateStandardFoodImage(foodID primitive.ObjectID, image *models.StoredImage) error {
+	filter := bson.M{"_id": foodID}
+	update := bson.M{"$set": bson.M{
+		"image_url":      image.URL,
+		"image_key":      image.Key,
+		"image_variants": image.Variants,
+	}}
+	_, err := r.standardFoodCollection.UpdateOne(context.TODO(), filter, update)
 	return err
 }
 
-func (r *foodRepository) SaveCustomFood(food *models.CustomFood) error {
-	_, err := r.customFoodCollection.InsertOne(context.TODO(), food)
+// input에 들어 있는 필드만 $set하고 바뀐 뒤의 문서를 돌려줌
+// 전체를 덮어쓰면 그 사이에 AddStandardFoodAliases 등으로 바뀐 필드가 되돌아가므로 필드 단위로 씀
+func (r *foodRepository) UpdateStandardFood(foodID primitive.ObjectID, input models.UpdateStandardFoodInput) (*models.StandardFood, error) {
+	set := bson.M{}
+	if input.Name != nil {
+		set["name"] = *input.Name
+	}
+	if input.ImageURL != nil {
+		set["image_url"] = *input.ImageURL
+	}
+	if input.Speed != nil {
+		set["speed"] = *input.Speed
+	}
+	if input.Type != nil {
+		set["type"] = *input.Type
+	}
+	if input.Categories != nil {
+		set["categories"] = *input.Categories
+	}
+	if input.Aliases != nil {
+		set["aliases"] = *input.Aliases
+	}
+	if input.EnglishName != nil {
+		set["english_name"] = *input.EnglishName
+	}
+
+	var food models.StandardFood
+	filter := bson.M{"_id": foodID}
+	if len(set) == 0 {
+		err := r.standardFoodCollection.FindOne(context.TODO(), filter).Decode(&food)
+		if err != nil {
+			return nil, err
+		}
+		return &food, nil
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := r.standardFoodCollection.FindOneAndUpdate(context.TODO(), filter, bson.M{"$set": set}, opts).Decode(&food)
+	if err != nil {
+		return nil, err
+	}
+	return &food, nil
+}
+
+func (r *foodRepository) AddStandardFoodAliases(foodID primitive.ObjectID, aliases []string) error {
+	filter := bson.M{"_id": foodID}
+	update := bson.M{"$addToSet": bson.M{"aliases": bson.M{"$each": aliases}}}
+	_, err := r.standardFoodCollection.UpdateOne(context.TODO(), filter, update)
 	return err
 }
 
-func (r *foodRepository) AddUserToCustomFood(foodID, userID primitive.ObjectID) error {
+func (r *foodRepository) RemoveStandardFoodAlias(foodID primitive.ObjectID, alias string) error {
 	filter := bson.M{"_id": foodID}
-	update := bson.M{"$addToSet": bson.M{"using_user_ids": userID}}
-	_, err := r.customFoodCollection.UpdateOne(context.TODO(), filter, update)
+	update := bson.M{"$pull": bson.M{"aliases": alias}}
+	_, err := r.standardFoodCollection.UpdateOne(context.TODO(), filter, update)
 	return err
 }
 
-func (r *foodRepository) UpdateCreatedReviewStats(foodIDs []primitive.ObjectID, rating int) error {
-	if len(foodIDs) == 0 {
-		return nil
+// 카탈로그 일괄 등록용. 기존 음식의 통계와 보관 여부는 건드리지 않고 기본 정보만 덮어씀
+func (r *foodRepository) UpsertStandardFoods(foods []*models.StandardFood) error {
+	writes := make([]mongo.WriteModel, 0, len(foods))
+	for _, food := range foods {
+		update := bson.M{
+			"$set": bson.M{
+				"name":         food.Name,
+				"image_url":    food.ImageURL,
+				"speed":        food.Speed,
+				"type":         food.Type,
+				"categories":   food.Categories,
+				"aliases":      food.Aliases,
+				"english_name": food.EnglishName,
+			},
+			"$setOnInsert": bson.M{
+				"like_count":   0,
+				"review_count": 0,
+				"total_rating": 0,
+				"archived":     false,
+			},
+		}
+		writes = append(writes, mongo.NewUpdateOneModel().SetFilter(bson.M{"_id": food.ID}).SetUpdate(update).SetUpsert(true))
 	}
-	if rating <= 0 || rating > 5 {
+	if len(writes) == 0 {
 		return nil
 	}
 
-	filter := bson.M{"_id": bson.M{"$in": foodIDs}}
-
-	incMap := bson.M{"review_count": 1}
-	incMap["total_rating"] = rating
+	_, err := r.standardFoodCollection.BulkWrite(context.TODO(), writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
 
-	update := bson.M{"$inc": incMap}
-	_, err := r.standardFoodCollection.UpdateMany(context.TODO(), filter, update)
+func (r *foodRepository) SetStandardFoodArchived(foodID primitive.ObjectID, archived bool) error {
+	filter := bson.M{"_id": foodID}
+	update := bson.M{"$set": bson.M{"archived": archived}}
+	_, err := r.standardFoodCollection.UpdateOne(context.TODO(), filter, update)
 	return err
 }
 
-func (r *foodRepository) UpdateModifiedReviewStats(foodIDs []primitive.ObjectID, oldRating, newRating int) error {
-	if len(foodIDs) == 0 {
-		return nil
+func (r *foodRepository) DeleteStandardFood(ctx context.Context, foodID primitive.ObjectID) (*models.StandardFood, error) {
+	var food models.StandardFood
+	err := r.standardFoodCollection.FindOneAndDelete(ctx, bson.M{"_id": foodID}).Decode(&food)
+	if err != nil {
+		return nil, err
 	}
-	if newRating <= 0 || newRating > 5 {
+	return &food, nil
+}
+
+func (r *foodRepository) DeleteCustomFood(ctx context.Context, foodID primitive.ObjectID) error {
+	_, err := r.customFoodCollection.DeleteOne(ctx, bson.M{"_id": foodID})
+	return err
+}
+
+func (r *foodRepository) AddUsersToCustomFood(ctx context.Context, foodID primitive.ObjectID, userIDs []primitive.ObjectID) error {
+	if len(userIDs) == 0 {
 		return nil
 	}
+	filter := bson.M{"_id": foodID}
+	update := bson.M{"$addToSet": bson.M{"using_user_ids": bson.M{"$each": userIDs}}}
+	_, err := r.customFoodCollection.UpdateOne(ctx, filter, update)
+	return err
+}
 
-	filter := bson.M{"_id": bson.M{"$in": foodIDs}}
+// 정규화한 이름이 같은 custom 음식이 있으면 사용자 목록만 합치고, 없으면 새로 만듦
+// normalized_name의 unique 색인 덕분에 동시에 여러 요청(또는 여러 서버)이 같은 이름을 만들어도 하나만 남음
+// 동시에 삽입하다 충돌하면 이긴 쪽 문서에 사용자를 더해서 돌려줌. created는 이번 호출로 새로 만들어졌는지 여부
+func (r *foodRepository) FindOrCreateCustomFood(ctx context.Context, name string, userIDs []primitive.ObjectID) (*models.CustomFood, bool, error) {
+	newID := primitive.NewObjectID()
+	filter := bson.M{"normalized_name": utils.NormalizeBasic(name)}
+	update := bson.M{
+		"$setOnInsert": bson.M{"_id": newID, "name": name, "created_at": time.Now()},
+		"$addToSet":    bson.M{"using_user_ids": bson.M{"$each": userIDs}},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
 
-	ratingDiff := newRating - oldRating
+	var food models.CustomFood
+	err := r.customFoodCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&food)
+	if mongo.IsDuplicateKeyError(err) {
+		// 다른 요청이 먼저 삽입했으므로 다시 시도하면 그 문서에 매칭됨
+		// 트랜잭션 안에서는 충돌 시점에 트랜잭션이 중단되므로 이 재시도도 에러를 돌려줌
+		err = r.customFoodCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&food)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return &food, food.ID == newID, nil
+}
 
-	incMap := bson.M{}
-	if ratingDiff != 0 {
-		incMap["total_rating"] = ratingDiff
-	} else {
+func (r *foodRepository) UpdateReviewStats(ctx context.Context, diffs []models.ReviewStatsDiff) error {
+	writes := make([]mongo.WriteModel, 0, len(diffs))
+	for _, diff := range diffs {
+		if diff.ReviewCount == 0 && diff.TotalRating == 0 {
+			continue
+		}
+
+		update := bson.M{"$inc": bson.M{
+			"review_count": diff.ReviewCount,
+			"total_rating": diff.TotalRating,
+		}}
+		writes = append(writes, mongo.NewUpdateOneModel().SetFilter(bson.M{"_id": diff.FoodID}).SetUpdate(update))
+	}
+	if len(writes) == 0 {
 		return nil
 	}
 
-	update := bson.M{"$inc": incMap}
-	_, err := r.standardFoodCollection.UpdateMany(context.TODO(), filter, update)
+	_, err := r.standardFoodCollection.BulkWrite(ctx, writes)
 	return err
 }
 
-func (r *foodRepository) IncrementLikeCount(foodID primitive.ObjectID) error {
+func (r *foodRepository) IncrementLikeCount(ctx context.Context, foodID primitive.ObjectID) error {
 	filter := bson.M{"_id": foodID}
 	update := bson.M{"$inc": bson.M{"like_count": 1}}
-	_, err := r.standardFoodCollection.UpdateOne(context.TODO(), filter, update)
+	_, err := r.standardFoodCollection.UpdateOne(ctx, filter, update)
 	return err
 }
 
-func (r *foodRepository) DecrementLikeCount(foodID primitive.ObjectID) error {
+func (r *foodRepository) DecrementLikeCount(ctx context.Context, foodID primitive.ObjectID) error {
 	filter := bson.M{"_id": foodID}
 	update := bson.M{"$inc": bson.M{"like_count": -1}}
-	_, err := r.standardFoodCollection.UpdateOne(context.TODO(), filter, update)
+	_, err := r.standardFoodCollection.UpdateOne(ctx, filter, update)
 	return err
 }
+
+func (r *foodRepository) SetFoodStats(ctx context.Context, stats models.FoodStats) error {
+	filter := bson.M{"_id": stats.FoodID}
+	update := bson.M{"$set": bson.M{
+		"like_count":   stats.LikeCount,
+		"review_count": stats.ReviewCount,
+		"total_rating": stats.TotalRating,
+	}}
+	_, err := r.standardFoodCollection.UpdateOne(ctx, filter, update)
+	return err
+}
+
+// 저장된 값과 실제 값의 차이만큼 $inc 하여, 재계산 도중 들어온 증감분을 덮어쓰지 않도록 함
+func (r *foodRepository) CorrectFoodStats(drifts []models.FoodStatsDrift) error {
+	writes := make([]mongo.WriteModel, 0, len(drifts))
+	for _, drift := range drifts {
+		update := bson.M{"$inc": bson.M{
+			"like_count":   drift.Actual.LikeCount - drift.Stored.LikeCount,
+			"review_count": drift.Actual.ReviewCount - drift.Stored.ReviewCount,
+			"total_rating": drift.Actual.TotalRating - drift.Stored.TotalRating,
+		}}
+		writes = append(writes, mongo.NewUpdateOneModel().SetFilter(bson.M{"_id": drift.FoodID}).SetUpdate(update))
+	}
+	if len(writes) == 0 {
+		return nil
+	}
+
+	_, err := r.standardFoodCollection.BulkWrite(context.TODO(), writes)
+	return err
+}
+
+const customFoodNameIndex = "normalized_name_1"
+
+// custom 음식의 normalized_name unique 색인을 만듦
+// 색인 도입 전에 만들어진 문서는 normalized_name을 채워 넣고, 이미 이름이 겹치는 문서가 있으면
+// 색인 생성이 실패하므로 관리자 CLI(dedupe-custom-foods)로 먼저 병합해야 함
+func (r *foodRepository) EnsureIndexes() error {
+	if err := r.BackfillCustomFoodNormalizedNames(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	index := mongo.IndexModel{
+		Keys:    bson.D{{Key: "normalized_name", Value: 1}},
+		Options: options.Index().SetName(customFoodNameIndex).SetUnique(true),
+	}
+	_, err := r.customFoodCollection.Indexes().CreateOne(ctx, index)
+	return err
+}
+
+// normalized_name 도입 전에 만들어진 custom 음식에 값을 채워 넣음
+func (r *foodRepository) BackfillCustomFoodNormalizedNames() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cursor, err := r.customFoodCollection.Find(ctx, bson.M{"normalized_name": bson.M{"$exists": false}})
+	if err != nil {
+		return err
+	}
+	var foods []models.CustomFood
+	if err := cursor.All(ctx, &foods); err != nil {
+		return err
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(foods))
+	for _, food := range foods {
+		update := bson.M{"$set": bson.M{"normalized_name": utils.NormalizeBasic(food.Name)}}
+		writes = append(writes, mongo.NewUpdateOneModel().SetFilter(bson.M{"_id": food.ID}).SetUpdate(update))
+	}
+	if len(writes) > 0 {
+		if _, err := r.customFoodCollection.BulkWrite(ctx, writes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// normalized_name unique 색인이 실제로 있는지 (없으면 custom 음식 중복 생성을 막지 못함)
+func (r *foodRepository) HasCustomFoodNameIndex() (bool, error) {
+	cursor, err := r.customFoodCollection.Indexes().List(context.TODO())
+	if err != nil {
+		return false, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var indexes []struct {
+		Name   string `bson:"name"`
+		Unique bool   `bson:"unique"`
+	}
+	if err := cursor.All(context.TODO(), &indexes); err != nil {
+		return false, err
+	}
+	for _, index := range indexes {
+		if index.Name == customFoodNameIndex && index.Unique {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// normalized_name이 같은 custom 음식 묶음들 (색인 도입 전에 만들어진 중복)
+func (r *foodRepository) FindDuplicateCustomFoods() ([][]models.CustomFood, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$normalized_name",
+			"foods": bson.M{"$push": "$$ROOT"},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": 1}}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	cursor, err := r.customFoodCollection.Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var groups []struct {
+		Foods []models.CustomFood `bson:"foods"`
+	}
+	if err := cursor.All(context.TODO(), &groups); err != nil {
+		return nil, err
+	}
+
+	duplicates := make([][]models.CustomFood, 0, len(groups))
+	for _, group := range groups {
+		duplicates = append(duplicates, group.Foods)
+	}
+	return duplicates, nil
+}
+
+// 다른 서버나 Mongo shell에서 바뀐 음식을 캐시에 반영하기 위한 change stream
+// resumeToken이 있으면 그 이후의 변경부터 이어서 받음 (레플리카셋에서만 동작)
+func (r *foodRepository) WatchStandardFoods(ctx context.Context, resumeToken bson.Raw) (*mongo.ChangeStream, error) {
+	return watchFoodCollection(ctx, r.standardFoodCollection, resumeToken)
+}
+
+func (r *foodRepository) WatchCustomFoods(ctx context.Context, resumeToken bson.Raw) (*mongo.ChangeStream, error) {
+	return watchFoodCollection(ctx, r.customFoodCollection, resumeToken)
+}
+
+func watchFoodCollection(ctx context.Context, collection *mongo.Collection, resumeToken bson.Raw) (*mongo.ChangeStream, error) {
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if resumeToken != nil {
+		opts.SetResumeAfter(resumeToken)
+	}
+	return collection.Watch(ctx, mongo.Pipeline{}, opts)
+}
@@ -4,18 +4,33 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/seojoonrp/bapddang-server/models"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type ReviewRepository interface {
-	SaveReview(review *models.Review) error
-	UpdateReview(review *models.Review) error
+	SaveReview(ctx context.Context, review *models.Review) error
+	UpdateReview(ctx context.Context, review *models.Review) error
 	FindByUserIDAndDay(userID primitive.ObjectID, day int) ([]models.Review, error)
-	FindByIDAndUserID(reviewID, userID primitive.ObjectID) (*models.Review, error)
+	FindHistory(query models.ReviewHistoryQuery) ([]models.Review, error)
+	FindRecentByUserID(userID primitive.ObjectID, since time.Time, limit int) ([]models.Review, error)
+	FindByIDAndUserID(ctx context.Context, reviewID, userID primitive.ObjectID) (*models.Review, error)
+	DeleteByIDAndUserID(ctx context.Context, reviewID, userID primitive.ObjectID) (*models.Review, error)
+	AggregateFoodReviewStats() ([]models.FoodStats, error)
+	AggregateFoodReviewStatsByFoodID(ctx context.Context, foodID primitive.ObjectID) (*models.FoodStats, error)
+	AggregateFoodMealTimeCounts() ([]models.FoodMealTimeCount, error)
+	CountByMealTime() ([]models.MealTimeCount, error)
+	ReplaceMealTime(from, to string) (int64, error)
+	FindUserIDsByFoodID(ctx context.Context, foodID primitive.ObjectID) ([]primitive.ObjectID, error)
+	ReplaceFoodInReviews(ctx context.Context, fromFoodID primitive.ObjectID, to models.ReviewedFoodItem) (int64, error)
+	DedupeFoodInReviews(ctx context.Context, foodID primitive.ObjectID) (int64, error)
+
+	EnsureIndexes() error
 }
 
 type reviewRepository struct {
@@ -26,25 +41,30 @@ func NewReviewRepository(coll *mongo.Collection) ReviewRepository {
 	return &reviewRepository{collection: coll}
 }
 
-func (r *reviewRepository) SaveReview(review *models.Review) error {
-	_, err := r.collection.InsertOne(context.TODO(), review)
+func (r *reviewRepository) SaveReview(ctx context.Context, review *models.Review) error {
+	_, err := r.collection.InsertOne(ctx, review)
 	return err
 }
 
-func (r *reviewRepository) UpdateReview(review *models.Review) error {
+func (r *reviewRepository) UpdateReview(ctx context.Context, review *models.Review) error {
 	filter := bson.M{"_id": review.ID}
 	update := bson.M{
 		"$set": bson.M{
-			"meal_time":  review.MealTime,
-			"tags":       review.Tags,
-			"image_url":  review.ImageURL,
-			"comment":    review.Comment,
-			"rating":     review.Rating,
-			"updated_at": review.UpdatedAt,
+			"name":           review.Name,
+			"foods":          review.Foods,
+			"speed":          review.Speed,
+			"meal_time":      review.MealTime,
+			"tags":           review.Tags,
+			"image_url":      review.ImageURL,
+			"image_key":      review.ImageKey,
+			"image_variants": review.ImageVariants,
+			"comment":        review.Comment,
+			"rating":         review.Rating,
+			"updated_at":     review.UpdatedAt,
 		},
 	}
 
-	_, err := r.collection.UpdateOne(context.TODO(), filter, update)
+	_, err := r.collection.UpdateOne(ctx, filter, update)
 	return err
 }
 
@@ -65,14 +85,341 @@ func (r *reviewRepository) FindByUserIDAndDay(userID primitive.ObjectID, day int
 	return reviews, nil
 }
 
-func (r *reviewRepository) FindByIDAndUserID(reviewID, userID primitive.ObjectID) (*models.Review, error) {
+// 최신순(_id 내림차순)으로 정렬하며, Cursor가 있으면 그 리뷰 이후부터 조회
+// 다음 페이지 존재 여부를 판단할 수 있도록 Limit보다 하나 더 가져옴
+func (r *reviewRepository) FindHistory(query models.ReviewHistoryQuery) ([]models.Review, error) {
+	filter := bson.M{"user_id": query.UserID}
+
+	if query.DayFrom != nil || query.DayTo != nil {
+		dayFilter := bson.M{}
+		if query.DayFrom != nil {
+			dayFilter["$gte"] = *query.DayFrom
+		}
+		if query.DayTo != nil {
+			dayFilter["$lte"] = *query.DayTo
+		}
+		filter["day"] = dayFilter
+	}
+	if query.CreatedFrom != nil || query.CreatedTo != nil {
+		createdFilter := bson.M{}
+		if query.CreatedFrom != nil {
+			createdFilter["$gte"] = *query.CreatedFrom
+		}
+		if query.CreatedTo != nil {
+			createdFilter["$lt"] = *query.CreatedTo
+		}
+		filter["created_at"] = createdFilter
+	}
+	if query.MealTime != "" {
+		filter["meal_time"] = query.MealTime
+	}
+	if query.Speed != "" {
+		filter["speed"] = query.Speed
+	}
+	if len(query.Tags) > 0 {
+		filter["tags"] = bson.M{"$all": query.Tags}
+	}
+	if query.MinRating > 0 {
+		filter["rating"] = bson.M{"$gte": query.MinRating}
+	}
+	if query.FoodID != nil {
+		filter["foods.food_id"] = *query.FoodID
+	}
+	if query.Cursor != nil {
+		filter["_id"] = bson.M{"$lt": *query.Cursor}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: -1}}).
+		SetLimit(int64(query.Limit + 1))
+
+	cursor, err := r.collection.Find(context.TODO(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	reviews := make([]models.Review, 0)
+	if err = cursor.All(context.TODO(), &reviews); err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+// since 이후에 작성된 리뷰를 최신순으로 최대 limit개 조회
+func (r *reviewRepository) FindRecentByUserID(userID primitive.ObjectID, since time.Time, limit int) ([]models.Review, error) {
+	filter := bson.M{"user_id": userID, "created_at": bson.M{"$gte": since}}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(context.TODO(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	reviews := make([]models.Review, 0)
+	if err = cursor.All(context.TODO(), &reviews); err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+func (r *reviewRepository) FindByIDAndUserID(ctx context.Context, reviewID, userID primitive.ObjectID) (*models.Review, error) {
 	var review models.Review
 
 	filter := bson.M{"_id": reviewID, "user_id": userID}
-	err := r.collection.FindOne(context.TODO(), filter).Decode(&review)
+	err := r.collection.FindOne(ctx, filter).Decode(&review)
 	if err != nil {
 		return nil, err
 	}
 
 	return &review, nil
 }
+
+func (r *reviewRepository) DeleteByIDAndUserID(ctx context.Context, reviewID, userID primitive.ObjectID) (*models.Review, error) {
+	var review models.Review
+
+	filter := bson.M{"_id": reviewID, "user_id": userID}
+	err := r.collection.FindOneAndDelete(ctx, filter).Decode(&review)
+	if err != nil {
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+// 한 리뷰에 같은 음식이 여러 번 들어 있어도 한 번만 셈
+func foodReviewStatsPipeline(foodFilter bson.M) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"rating": bson.M{"$gte": 1, "$lte": 5}}}},
+		{{Key: "$unwind", Value: "$foods"}},
+		{{Key: "$match", Value: foodFilter}},
+		{{Key: "$group", Value: bson.M{
+			"_id":    bson.M{"review_id": "$_id", "food_id": "$foods.food_id"},
+			"rating": bson.M{"$first": "$rating"},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":          "$_id.food_id",
+			"review_count": bson.M{"$sum": 1},
+			"total_rating": bson.M{"$sum": "$rating"},
+		}}},
+	}
+}
+
+// reviews 컬렉션으로부터 standard 음식별 review_count / total_rating을 다시 계산
+func (r *reviewRepository) AggregateFoodReviewStats() ([]models.FoodStats, error) {
+	pipeline := foodReviewStatsPipeline(bson.M{"foods.food_type": "standard"})
+
+	cursor, err := r.collection.Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var stats []models.FoodStats
+	if err = cursor.All(context.TODO(), &stats); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func (r *reviewRepository) AggregateFoodReviewStatsByFoodID(ctx context.Context, foodID primitive.ObjectID) (*models.FoodStats, error) {
+	pipeline := append(
+		mongo.Pipeline{{{Key: "$match", Value: bson.M{"foods.food_id": foodID}}}},
+		foodReviewStatsPipeline(bson.M{"foods.food_id": foodID})...,
+	)
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var stats []models.FoodStats
+	if err = cursor.All(ctx, &stats); err != nil {
+		return nil, err
+	}
+
+	if len(stats) == 0 {
+		return &models.FoodStats{FoodID: foodID}, nil
+	}
+	return &stats[0], nil
+}
+
+// standard 음식별, meal_time별 리뷰 수 (한 리뷰에 같은 음식이 여러 번 들어 있어도 한 번만 셈)
+func (r *reviewRepository) AggregateFoodMealTimeCounts() ([]models.FoodMealTimeCount, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"meal_time": bson.M{"$nin": bson.A{"", nil}}}}},
+		{{Key: "$unwind", Value: "$foods"}},
+		{{Key: "$match", Value: bson.M{"foods.food_type": "standard"}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":       bson.M{"review_id": "$_id", "food_id": "$foods.food_id"},
+			"meal_time": bson.M{"$first": "$meal_time"},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"food_id": "$_id.food_id", "meal_time": "$meal_time"},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":       0,
+			"food_id":   "$_id.food_id",
+			"meal_time": "$_id.meal_time",
+			"count":     1,
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	counts := make([]models.FoodMealTimeCount, 0)
+	if err = cursor.All(context.TODO(), &counts); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// 저장된 meal_time 값별 리뷰 수 (값이 없는 리뷰는 "")
+func (r *reviewRepository) CountByMealTime() ([]models.MealTimeCount, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"$ifNull": bson.A{"$meal_time", ""}},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":       0,
+			"meal_time": "$_id",
+			"count":     1,
+		}}},
+		{{Key: "$sort", Value: bson.M{"meal_time": 1}}},
+	}
+
+	cursor, err := r.collection.Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	counts := make([]models.MealTimeCount, 0)
+	if err = cursor.All(context.TODO(), &counts); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (r *reviewRepository) ReplaceMealTime(from, to string) (int64, error) {
+	result, err := r.collection.UpdateMany(context.TODO(), bson.M{"meal_time": from}, bson.M{"$set": bson.M{"meal_time": to}})
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func (r *reviewRepository) FindUserIDsByFoodID(ctx context.Context, foodID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	values, err := r.collection.Distinct(ctx, "user_id", bson.M{"foods.food_id": foodID})
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]primitive.ObjectID, 0, len(values))
+	for _, value := range values {
+		if userID, ok := value.(primitive.ObjectID); ok {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	return userIDs, nil
+}
+
+// 리뷰의 음식 목록에서 fromFoodID를 가리키는 항목을 모두 to로 바꿈
+func (r *reviewRepository) ReplaceFoodInReviews(ctx context.Context, fromFoodID primitive.ObjectID, to models.ReviewedFoodItem) (int64, error) {
+	filter := bson.M{"foods.food_id": fromFoodID}
+	update := bson.M{"$set": bson.M{
+		"foods.$[item].food_id":   to.FoodID,
+		"foods.$[item].food_type": to.FoodType,
+	}}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []interface{}{bson.M{"item.food_id": fromFoodID}},
+	})
+
+	result, err := r.collection.UpdateMany(ctx, filter, update, opts)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// 음식 병합 후 한 리뷰에 같은 음식이 여러 번 남은 경우 첫 항목만 남김
+func (r *reviewRepository) DedupeFoodInReviews(ctx context.Context, foodID primitive.ObjectID) (int64, error) {
+	filter := bson.M{
+		"foods.food_id": foodID,
+		"$expr": bson.M{"$gt": bson.A{
+			bson.M{"$size": bson.M{"$filter": bson.M{
+				"input": "$foods",
+				"cond":  bson.M{"$eq": bson.A{"$$this.food_id", foodID}},
+			}}},
+			1,
+		}},
+	}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var reviews []models.Review
+	if err = cursor.All(ctx, &reviews); err != nil {
+		return 0, err
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(reviews))
+	for _, review := range reviews {
+		foods := make([]models.ReviewedFoodItem, 0, len(review.Foods))
+		seen := false
+		for _, item := range review.Foods {
+			if item.FoodID == foodID {
+				if seen {
+					continue
+				}
+				seen = true
+			}
+			foods = append(foods, item)
+		}
+
+		update := bson.M{"$set": bson.M{"foods": foods}}
+		writes = append(writes, mongo.NewUpdateOneModel().SetFilter(bson.M{"_id": review.ID}).SetUpdate(update))
+	}
+	if len(writes) == 0 {
+		return 0, nil
+	}
+
+	result, err := r.collection.BulkWrite(ctx, writes)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func (r *reviewRepository) EnsureIndexes() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "day", Value: -1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "foods.food_id", Value: 1}, {Key: "_id", Value: -1}}},
+	}
+
+	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
@@ -0,0 +1,39 @@
+// api/repositories/transaction_manager.go
+
+// 여러 컬렉션에 걸친 쓰기를 하나의 MongoDB 트랜잭션으로 묶어 실행
+// 트랜잭션은 replica set 환경에서만 동작함
+
+package repositories
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type TransactionManager interface {
+	WithTransaction(fn func(ctx context.Context) error) error
+}
+
+type transactionManager struct {
+	client *mongo.Client
+}
+
+func NewTransactionManager(client *mongo.Client) TransactionManager {
+	return &transactionManager{client: client}
+}
+
+// fn 안의 repository 호출에는 반드시 넘겨받은 ctx를 전달해야 트랜잭션에 포함됨
+// 일시적인 오류가 발생하면 드라이버가 fn을 재시도할 수 있으므로 fn은 부수효과 없이 작성해야 함
+func (m *transactionManager) WithTransaction(fn func(ctx context.Context) error) error {
+	session, err := m.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(context.TODO())
+
+	_, err = session.WithTransaction(context.TODO(), func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	})
+	return err
+}
@@ -0,0 +1,102 @@
+// api/repositories/upload_repository.go
+
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/seojoonrp/bapddang-server/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type UploadRepository interface {
+	Save(upload *models.Upload) error
+	FindByKey(key string) (*models.Upload, error)
+	MarkAttached(ctx context.Context, key string) error
+	MarkProcessed(key string) error
+	FindPendingCreatedBefore(before time.Time) ([]models.Upload, error)
+	DeleteByKey(key string) error
+
+	EnsureIndexes() error
+}
+
+type uploadRepository struct {
+	collection *mongo.Collection
+}
+
+func NewUploadRepository(coll *mongo.Collection) UploadRepository {
+	return &uploadRepository{collection: coll}
+}
+
+func (r *uploadRepository) Save(upload *models.Upload) error {
+	_, err := r.collection.InsertOne(context.TODO(), upload)
+	return err
+}
+
+func (r *uploadRepository) FindByKey(key string) (*models.Upload, error) {
+	var upload models.Upload
+	err := r.collection.FindOne(context.TODO(), bson.M{"key": key}).Decode(&upload)
+	if err != nil {
+		return nil, err
+	}
+	return &upload, nil
+}
+
+// 아직 어느 리뷰에도 연결되지 않은 업로드만 연결함. 이미 연결되었거나 없는 업로드면 mongo.ErrNoDocuments
+func (r *uploadRepository) MarkAttached(ctx context.Context, key string) error {
+	filter := bson.M{"key": key, "status": models.UploadStatusPending}
+	update := bson.M{"$set": bson.M{"status": models.UploadStatusAttached}}
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *uploadRepository) MarkProcessed(key string) error {
+	filter := bson.M{"key": key}
+	update := bson.M{"$set": bson.M{"processed": true}}
+	_, err := r.collection.UpdateOne(context.TODO(), filter, update)
+	return err
+}
+
+func (r *uploadRepository) FindPendingCreatedBefore(before time.Time) ([]models.Upload, error) {
+	var uploads []models.Upload
+
+	filter := bson.M{"status": models.UploadStatusPending, "created_at": bson.M{"$lt": before}}
+	cursor, err := r.collection.Find(context.TODO(), filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	if err = cursor.All(context.TODO(), &uploads); err != nil {
+		return nil, err
+	}
+
+	return uploads, nil
+}
+
+func (r *uploadRepository) DeleteByKey(key string) error {
+	_, err := r.collection.DeleteOne(context.TODO(), bson.M{"key": key})
+	return err
+}
+
+func (r *uploadRepository) EnsureIndexes() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "key", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}}},
+	}
+
+	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
@@ -15,9 +15,14 @@ type UserRepository interface {
 	FindByUsername(username string) (*models.User, error)
 	FindByEmail(email string) (*models.User, error)
 	Save(user *models.User) error
-	AddLikedFood(userID, foodID primitive.ObjectID) (bool, error)
-	RemoveLikedFood(userID, foodID primitive.ObjectID) (bool, error)
+	UpdateRoleByUsername(username, role string) (bool, error)
+	AddLikedFood(ctx context.Context, userID, foodID primitive.ObjectID) (bool, error)
+	RemoveLikedFood(ctx context.Context, userID, foodID primitive.ObjectID) (bool, error)
 	GetLikedFoodIDs(userID primitive.ObjectID) ([]primitive.ObjectID, error)
+	RemoveLikedFoodFromAll(ctx context.Context, foodID primitive.ObjectID) (int64, error)
+	ReplaceLikedFood(ctx context.Context, fromFoodID, toFoodID primitive.ObjectID) (int64, error)
+	CountUsersLikingFood(ctx context.Context, foodID primitive.ObjectID) (int64, error)
+	AggregateFoodLikeCounts() ([]models.FoodStats, error)
 }
 
 type userRepository struct {
@@ -54,22 +59,33 @@ func (r *userRepository) Save(user *models.User) error {
 	return err
 }
 
-func (r *userRepository) AddLikedFood(userID, foodID primitive.ObjectID) (bool, error) {
+func (r *userRepository) UpdateRoleByUsername(username, role string) (bool, error) {
+	filter := bson.M{"username": username}
+	update := bson.M{"$set": bson.M{"role": role}}
+
+	result, err := r.collection.UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func (r *userRepository) AddLikedFood(ctx context.Context, userID, foodID primitive.ObjectID) (bool, error) {
 	filter := bson.M{"_id": userID}
 	update := bson.M{"$addToSet": bson.M{"liked_food_ids": foodID}}
 
-	result, err := r.collection.UpdateOne(context.TODO(), filter, update)
+	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return false, err
 	}
 	return result.ModifiedCount > 0, nil
 }
 
-func (r *userRepository) RemoveLikedFood(userID, foodID primitive.ObjectID) (bool, error) {
+func (r *userRepository) RemoveLikedFood(ctx context.Context, userID, foodID primitive.ObjectID) (bool, error) {
 	filter := bson.M{"_id": userID}
 	update := bson.M{"$pull": bson.M{"liked_food_ids": foodID}}
 
-	result, err := r.collection.UpdateOne(context.TODO(), filter, update)
+	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return false, err
 	}
@@ -84,3 +100,57 @@ func (r *userRepository) GetLikedFoodIDs(userID primitive.ObjectID) ([]primitive
 	}
 	return user.LikedFoodIDs, nil
 }
+
+func (r *userRepository) RemoveLikedFoodFromAll(ctx context.Context, foodID primitive.ObjectID) (int64, error) {
+	filter := bson.M{"liked_food_ids": foodID}
+	update := bson.M{"$pull": bson.M{"liked_food_ids": foodID}}
+
+	result, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// 같은 필드에 $addToSet과 $pull을 한 번에 쓸 수 없어서 두 번에 나눠 갱신함
+func (r *userRepository) ReplaceLikedFood(ctx context.Context, fromFoodID, toFoodID primitive.ObjectID) (int64, error) {
+	filter := bson.M{"liked_food_ids": fromFoodID}
+	_, err := r.collection.UpdateMany(ctx, filter, bson.M{"$addToSet": bson.M{"liked_food_ids": toFoodID}})
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := r.collection.UpdateMany(ctx, filter, bson.M{"$pull": bson.M{"liked_food_ids": fromFoodID}})
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func (r *userRepository) CountUsersLikingFood(ctx context.Context, foodID primitive.ObjectID) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"liked_food_ids": foodID})
+}
+
+// 모든 유저의 liked_food_ids로부터 음식별 like_count를 다시 계산
+func (r *userRepository) AggregateFoodLikeCounts() ([]models.FoodStats, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$unwind", Value: "$liked_food_ids"}},
+		{{Key: "$group", Value: bson.M{
+			"_id":        "$liked_food_ids",
+			"like_count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var stats []models.FoodStats
+	if err = cursor.All(context.TODO(), &stats); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
@@ -3,6 +3,7 @@
 package routes
 
 import (
+	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -10,6 +11,7 @@ import (
 	"github.com/seojoonrp/bapddang-server/api/middleware"
 	"github.com/seojoonrp/bapddang-server/api/repositories"
 	"github.com/seojoonrp/bapddang-server/api/services"
+	"github.com/seojoonrp/bapddang-server/storage"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
@@ -20,17 +22,55 @@ func SetupRoutes(router *gin.Engine, db *mongo.Database) {
 	standardFoodCollection := db.Collection("standard_foods")
 	customFoodCollection := db.Collection("custom_foods")
 	foodRepository := repositories.NewFoodRepository(standardFoodCollection, customFoodCollection)
+	// 실패해도 서버는 띄우되, custom 음식 중복 생성을 막지 못하므로 /admin/food-cache에서도 보이게 함
+	if err := foodRepository.EnsureIndexes(); err != nil {
+		log.Printf("ERROR: Failed to create food indexes, run `admin dedupe-custom-foods` if duplicates exist: %v", err)
+	}
+	foodRedirectRepository := repositories.NewFoodRedirectRepository(db.Collection("food_redirects"))
 
 	reviewCollection := db.Collection("reviews")
 	reviewRepository := repositories.NewReviewRepository(reviewCollection)
+	if err := reviewRepository.EnsureIndexes(); err != nil {
+		log.Printf("WARNING: Failed to create review indexes: %v", err)
+	}
 
-	userService := services.NewUserService(userRepository, foodRepository)
-	foodService := services.NewFoodService(foodRepository)
-	reviewService := services.NewReviewService(reviewRepository, foodRepository)
+	uploadCollection := db.Collection("uploads")
+	uploadRepository := repositories.NewUploadRepository(uploadCollection)
+	if err := uploadRepository.EnsureIndexes(); err != nil {
+		log.Printf("WARNING: Failed to create upload indexes: %v", err)
+	}
+
+	txManager := repositories.NewTransactionManager(db.Client())
+
+	objectStorage, err := storage.New()
+	if err != nil {
+		log.Fatal("FATAL: Failed to initialize object storage: ", err)
+	}
+
+	foodService := services.NewFoodService(foodRepository, foodRedirectRepository)
+	foodService.StartCacheSync()
+	userService := services.NewUserService(userRepository, foodRepository, foodService, txManager)
+	imageService := services.NewImageService(objectStorage)
+	uploadService := services.NewUploadService(uploadRepository, objectStorage, imageService)
+	uploadService.StartOrphanCleanup()
+	reviewService := services.NewReviewService(reviewRepository, foodRepository, foodService, uploadService, txManager)
+	statsService := services.NewStatsService(foodRepository, reviewRepository, userRepository)
+	foodAdminService := services.NewFoodAdminService(foodRepository, foodRedirectRepository, reviewRepository, userRepository, foodService, txManager)
+	foodCatalogService := services.NewFoodCatalogService(foodRepository, foodService)
+	feedService := services.NewFeedService(foodService, reviewRepository)
+	feedService.StartMealTimeStatsSync()
 
 	userHandler := handlers.NewUserHandler(userService, foodService)
-	foodHandler := handlers.NewFoodHandler(foodService)
-	reviewHandler := handlers.NewReviewHandler(reviewService, foodService)
+	foodHandler := handlers.NewFoodHandler(foodService, feedService, imageService)
+	reviewHandler := handlers.NewReviewHandler(reviewService)
+	adminHandler := handlers.NewAdminHandler(statsService, foodService, foodAdminService, foodCatalogService, imageService)
+	uploadHandler := handlers.NewUploadHandler(uploadService)
+
+	if localStorage, ok := objectStorage.(*storage.LocalStorage); ok {
+		storageHandler := handlers.NewStorageHandler(localStorage)
+		router.PUT(storage.LocalRoutePrefix+"*key", storageHandler.UploadObject)
+		router.GET(storage.LocalRoutePrefix+"*key", storageHandler.ServeObject)
+	}
 
 	apiV1 := router.Group("/api/v1")
 	{
@@ -63,15 +103,36 @@ func SetupRoutes(router *gin.Engine, db *mongo.Database) {
 			protected.POST("/foods/validate", foodHandler.ValidateFoods)
 
 			protected.POST("/reviews", reviewHandler.CreateReview)
+			protected.PUT("/reviews/:reviewID", reviewHandler.UpdateReview)
+			protected.DELETE("/reviews/:reviewID", reviewHandler.DeleteReview)
 			protected.GET("/reviews/me", reviewHandler.GetMyReviewsByDay)
+			protected.GET("/reviews/me/history", reviewHandler.GetMyReviewHistory)
+
+			protected.POST("/uploads/review-image", uploadHandler.CreateReviewImageUpload)
 		}
 
 		apiV1.GET("/foods/:foodID", foodHandler.GetStandardFoodByID)
-		apiV1.GET("/foods/main-feed", foodHandler.GetMainFeedFoods)
+		apiV1.GET("/foods/main-feed", middleware.OptionalAuthMiddleware(userCollection), foodHandler.GetMainFeedFoods)
+		apiV1.GET("/foods/search", foodHandler.SearchFoods)
 
 		adminRoutes := apiV1.Group("/admin")
+		adminRoutes.Use(middleware.AuthMiddleware(userCollection), middleware.AdminMiddleware())
 		{
 			adminRoutes.POST("/new-food", foodHandler.CreateStandardFood)
+			adminRoutes.POST("/foods/import", adminHandler.ImportStandardFoods)
+			adminRoutes.GET("/foods/export", adminHandler.ExportStandardFoods)
+			adminRoutes.PATCH("/foods/:foodID", adminHandler.UpdateStandardFood)
+			adminRoutes.DELETE("/foods/:foodID", adminHandler.DeleteStandardFood)
+			adminRoutes.POST("/foods/:foodID/archive", adminHandler.ArchiveStandardFood)
+			adminRoutes.DELETE("/foods/:foodID/archive", adminHandler.UnarchiveStandardFood)
+			adminRoutes.POST("/foods/:foodID/aliases", adminHandler.AddStandardFoodAliases)
+			adminRoutes.DELETE("/foods/:foodID/aliases/:alias", adminHandler.RemoveStandardFoodAlias)
+			adminRoutes.POST("/foods/:foodID/image", foodHandler.UploadStandardFoodImage)
+			adminRoutes.POST("/foods/merge", adminHandler.MergeFoods)
+			adminRoutes.GET("/custom-foods/popular", adminHandler.ListPopularCustomFoods)
+			adminRoutes.POST("/custom-foods/:foodID/promote", adminHandler.PromoteCustomFood)
+			adminRoutes.POST("/food-stats/reconcile", adminHandler.ReconcileFoodStats)
+			adminRoutes.GET("/food-cache", adminHandler.GetFoodCacheStatus)
 		}
 	}
 }
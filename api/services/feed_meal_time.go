@@ -0,0 +1,113 @@
+// api/services/feed_meal_time.go
+
+package services
+
+import (
+	"math"
+
+	"github.com/seojoonrp/bapddang-server/models"
+	"github.com/seojoonrp/bapddang-server/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 끼니(아침/점심/저녁/야식)에 맞는 음식 추천
+// 전체 유저가 그 끼니에 많이 리뷰한 음식과, 이 유저가 그 끼니에 자주 먹는 카테고리를 올려줌
+// feed_scoring.go와 마찬가지로 DB에 접근하지 않는 순수 함수들로만 구성함
+
+const (
+	feedMealTimeGlobalWeight = 0.4
+	feedMealTimeUserWeight   = 0.3
+
+	// 리뷰가 적은 음식/카테고리는 끼니 비율을 전체 평균 쪽으로 당김
+	feedMealTimeGlobalPrior = 5.0
+	feedMealTimeUserPrior   = 2.0
+)
+
+const (
+	feedReasonMealTime   = "meal_time"
+	feedReasonMyMealTime = "my_meal_time"
+)
+
+// 전체 리뷰 기준 음식별 끼니 통계
+type mealTimeStats struct {
+	foodCounts map[primitive.ObjectID]map[string]int
+	foodTotals map[primitive.ObjectID]int
+	slotCounts map[string]int
+	total      int
+}
+
+// 리뷰에 저장된 meal_time을 끼니 값으로 모아서 집계함. 알 수 없는 meal_time은 버림
+func buildMealTimeStats(counts []models.FoodMealTimeCount) *mealTimeStats {
+	stats := &mealTimeStats{
+		foodCounts: make(map[primitive.ObjectID]map[string]int),
+		foodTotals: make(map[primitive.ObjectID]int),
+		slotCounts: make(map[string]int),
+	}
+
+	for _, count := range counts {
+		mealTime := utils.NormalizeMealTime(count.MealTime)
+		if mealTime == "" || count.Count <= 0 {
+			continue
+		}
+		if stats.foodCounts[count.FoodID] == nil {
+			stats.foodCounts[count.FoodID] = make(map[string]int)
+		}
+		stats.foodCounts[count.FoodID][mealTime] += count.Count
+		stats.foodTotals[count.FoodID] += count.Count
+		stats.slotCounts[mealTime] += count.Count
+		stats.total += count.Count
+	}
+
+	return stats
+}
+
+// 그 끼니에 리뷰된 비율이 기준 비율보다 얼마나 높은지 (-1 ~ 1)
+// 기준 비율과 같으면 0, 그 끼니에만 리뷰되었으면 1, 그 끼니에 한 번도 리뷰되지 않았으면 -1에 가까움
+func mealTimeAffinity(slotCount, total, baseRate, prior float64) float64 {
+	if baseRate <= 0 || baseRate >= 1 || total <= 0 {
+		return 0
+	}
+
+	share := (slotCount + prior*baseRate) / (total + prior)
+	if share >= baseRate {
+		return (share - baseRate) / (1 - baseRate)
+	}
+	return (share - baseRate) / baseRate
+}
+
+// 음식 하나의 끼니 점수와 그 구성 항목들. stats나 profile이 nil이면 해당 항목은 건너뜀
+func scoreMealTime(food *models.StandardFood, mealTime string, stats *mealTimeStats, profile *feedProfile) (float64, []models.FeedReason) {
+	score := 0.0
+	reasons := make([]models.FeedReason, 0)
+
+	if stats != nil && stats.total > 0 {
+		baseRate := float64(stats.slotCounts[mealTime]) / float64(stats.total)
+		affinity := mealTimeAffinity(float64(stats.foodCounts[food.ID][mealTime]), float64(stats.foodTotals[food.ID]), baseRate, feedMealTimeGlobalPrior)
+		if affinity != 0 {
+			globalScore := feedMealTimeGlobalWeight * affinity
+			score += globalScore
+			reasons = append(reasons, models.FeedReason{Code: feedReasonMealTime, Score: globalScore, Detail: mealTime})
+		}
+	}
+
+	if profile != nil && profile.mealTimeTotal > 0 {
+		// 이 유저가 평소에 그 끼니를 얼마나 기록하는지 (끼니 4개에 1씩 더해 리뷰가 적어도 0이나 1이 되지 않게 함)
+		baseRate := (profile.mealTimeSlots[mealTime] + 1) / (profile.mealTimeTotal + float64(len(utils.MealTimes)))
+
+		// 여러 카테고리에 속하면 가장 강하게 반응한 카테고리 하나만 반영
+		bestCategory, bestAffinity := "", 0.0
+		for _, category := range food.Categories {
+			affinity := mealTimeAffinity(profile.categoryMealTimes[category][mealTime], profile.categoryMealTimeTotals[category], baseRate, feedMealTimeUserPrior)
+			if math.Abs(affinity) > math.Abs(bestAffinity) {
+				bestCategory, bestAffinity = category, affinity
+			}
+		}
+		if bestCategory != "" {
+			userScore := feedMealTimeUserWeight * bestAffinity
+			score += userScore
+			reasons = append(reasons, models.FeedReason{Code: feedReasonMyMealTime, Score: userScore, Detail: bestCategory})
+		}
+	}
+
+	return score, reasons
+}
@@ -0,0 +1,154 @@
+// api/services/feed_scoring.go
+
+package services
+
+import (
+	"math"
+	"time"
+
+	"github.com/seojoonrp/bapddang-server/models"
+	"github.com/seojoonrp/bapddang-server/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 로그인한 유저의 메인 피드 개인화 점수
+// DB나 캐시에 접근하지 않는 순수 함수들로만 구성해서, 어떤 입력이 어떤 점수를 만드는지 따로 떼어 확인할 수 있도록 함
+
+const (
+	feedProfileWindow     = 90 * 24 * time.Hour // 취향을 계산할 때 보는 리뷰 기간
+	feedProfileMaxReviews = 200
+
+	feedLikedWeight    = 0.5
+	feedCategoryWeight = 0.4
+
+	// 최근 feedRecentlyEatenDays일 안에 먹은 음식은 점수를 깎고, 먹은 지 오래될수록 덜 깎음
+	feedRecentlyEatenDays   = 3
+	feedRecentlyEatenWeight = 0.8
+
+	// 리뷰가 적은 카테고리의 취향이 과하게 반영되지 않도록 평균을 0 쪽으로 당기는 정도
+	feedCategoryPrior = 2.0
+	// 오래된 리뷰일수록 취향에 덜 반영됨 (반감기)
+	feedReviewHalfLife = 30 * 24 * time.Hour
+)
+
+const (
+	feedReasonLiked         = "liked"
+	feedReasonCategory      = "category"
+	feedReasonRecentlyEaten = "recently_eaten"
+)
+
+type feedProfile struct {
+	likedFoodIDs map[primitive.ObjectID]bool
+	// 카테고리별 선호도 (-1 ~ 1). 평점 3점을 0으로 보고 5점이면 1, 1점이면 -1
+	categoryAffinity map[string]float64
+	lastEatenAt      map[primitive.ObjectID]time.Time
+
+	// 끼니별로 어떤 카테고리를 먹었는지 (최근 리뷰일수록 크게 셈)
+	categoryMealTimes      map[string]map[string]float64
+	categoryMealTimeTotals map[string]float64
+	mealTimeSlots          map[string]float64
+	mealTimeTotal          float64
+}
+
+// 좋아요 목록과 최근 리뷰로 유저의 취향을 만듦. foods는 리뷰에 나온 standard 음식들 (카테고리 조회용)
+func buildFeedProfile(likedFoodIDs []primitive.ObjectID, reviews []models.Review, foods map[primitive.ObjectID]*models.StandardFood, now time.Time) feedProfile {
+	profile := feedProfile{
+		likedFoodIDs:     make(map[primitive.ObjectID]bool, len(likedFoodIDs)),
+		categoryAffinity: make(map[string]float64),
+		lastEatenAt:      make(map[primitive.ObjectID]time.Time),
+
+		categoryMealTimes:      make(map[string]map[string]float64),
+		categoryMealTimeTotals: make(map[string]float64),
+		mealTimeSlots:          make(map[string]float64),
+	}
+	for _, foodID := range likedFoodIDs {
+		profile.likedFoodIDs[foodID] = true
+	}
+
+	ratingSums := make(map[string]float64)
+	weightSums := make(map[string]float64)
+
+	for _, review := range reviews {
+		weight := math.Pow(0.5, float64(now.Sub(review.CreatedAt))/float64(feedReviewHalfLife))
+		reviewCategories := make(map[string]bool)
+
+		for _, item := range review.Foods {
+			if eatenAt, exists := profile.lastEatenAt[item.FoodID]; !exists || review.CreatedAt.After(eatenAt) {
+				profile.lastEatenAt[item.FoodID] = review.CreatedAt
+			}
+
+			food, exists := foods[item.FoodID]
+			if !exists {
+				continue
+			}
+			for _, category := range food.Categories {
+				reviewCategories[category] = true
+			}
+		}
+
+		if mealTime := utils.NormalizeMealTime(review.MealTime); mealTime != "" {
+			profile.mealTimeSlots[mealTime] += weight
+			profile.mealTimeTotal += weight
+			for category := range reviewCategories {
+				if profile.categoryMealTimes[category] == nil {
+					profile.categoryMealTimes[category] = make(map[string]float64)
+				}
+				profile.categoryMealTimes[category][mealTime] += weight
+				profile.categoryMealTimeTotals[category] += weight
+			}
+		}
+
+		// 평점이 없는 리뷰는 먹은 기록으로만 씀
+		if !isCountedRating(review.Rating) {
+			continue
+		}
+		// 한 리뷰에 같은 카테고리 음식이 여러 개 있어도 한 번만 반영
+		normalizedRating := float64(review.Rating-3) / 2
+		for category := range reviewCategories {
+			ratingSums[category] += normalizedRating * weight
+			weightSums[category] += weight
+		}
+	}
+
+	for category, weightSum := range weightSums {
+		profile.categoryAffinity[category] = ratingSums[category] / (weightSum + feedCategoryPrior)
+	}
+
+	return profile
+}
+
+// 음식 하나의 개인화 점수와 그 구성 항목들
+func scoreFeedFood(food *models.StandardFood, profile feedProfile, now time.Time) (float64, []models.FeedReason) {
+	score := 0.0
+	reasons := make([]models.FeedReason, 0)
+
+	if profile.likedFoodIDs[food.ID] {
+		score += feedLikedWeight
+		reasons = append(reasons, models.FeedReason{Code: feedReasonLiked, Score: feedLikedWeight})
+	}
+
+	// 여러 카테고리에 속하면 가장 강하게 반응한 (좋든 싫든) 카테고리 하나만 반영
+	bestCategory, bestAffinity := "", 0.0
+	for _, category := range food.Categories {
+		affinity := profile.categoryAffinity[category]
+		if math.Abs(affinity) > math.Abs(bestAffinity) {
+			bestCategory, bestAffinity = category, affinity
+		}
+	}
+	if bestCategory != "" {
+		categoryScore := feedCategoryWeight * bestAffinity
+		score += categoryScore
+		reasons = append(reasons, models.FeedReason{Code: feedReasonCategory, Score: categoryScore, Detail: bestCategory})
+	}
+
+	if eatenAt, exists := profile.lastEatenAt[food.ID]; exists {
+		daysAgo := now.Sub(eatenAt).Hours() / 24
+		if daysAgo < feedRecentlyEatenDays {
+			penalty := -feedRecentlyEatenWeight * (1 - max(daysAgo, 0)/feedRecentlyEatenDays)
+			score += penalty
+			reasons = append(reasons, models.FeedReason{Code: feedReasonRecentlyEaten, Score: penalty})
+		}
+	}
+
+	return score, reasons
+}
@@ -0,0 +1,166 @@
+// api/services/feed_scoring_test.go
+
+package services
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/seojoonrp/bapddang-server/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const scoreTolerance = 1e-9
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < scoreTolerance
+}
+
+func emptyFeedProfile() feedProfile {
+	return buildFeedProfile(nil, nil, nil, time.Now())
+}
+
+func TestBuildFeedProfileCategoryAffinity(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	gukbap := &models.StandardFood{ID: primitive.NewObjectID(), Categories: []string{"국밥"}}
+	foods := map[primitive.ObjectID]*models.StandardFood{gukbap.ID: gukbap}
+
+	review := func(rating int, age time.Duration) models.Review {
+		return models.Review{
+			Rating:    rating,
+			CreatedAt: now.Add(-age),
+			Foods:     []models.ReviewedFoodItem{{FoodID: gukbap.ID, FoodType: "standard"}},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		reviews []models.Review
+		want    float64
+	}{
+		// (5-3)/2 = 1, 가중치 1 -> 1 / (1 + prior 2)
+		{"one fresh top rating is shrunk by the prior", []models.Review{review(5, 0)}, 1.0 / 3},
+		// 반감기만큼 지난 리뷰는 가중치 0.5 -> 0.5 / (0.5 + 2)
+		{"review one half-life old counts half", []models.Review{review(5, feedReviewHalfLife)}, 0.2},
+		{"low rating gives negative affinity", []models.Review{review(1, 0)}, -1.0 / 3},
+		// 5점(가중치 1)과 1점(가중치 0.5): (1 - 0.5) / (1.5 + 2)
+		{"recent reviews outweigh old ones", []models.Review{review(5, 0), review(1, feedReviewHalfLife)}, 0.5 / 3.5},
+		{"unrated review does not change affinity", []models.Review{review(0, 0)}, 0},
+		{"many reviews approach the mean", []models.Review{review(5, 0), review(5, 0), review(5, 0), review(5, 0), review(5, 0), review(5, 0), review(5, 0), review(5, 0)}, 8.0 / 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := buildFeedProfile(nil, tt.reviews, foods, now)
+			if got := profile.categoryAffinity["국밥"]; !approxEqual(got, tt.want) {
+				t.Errorf("categoryAffinity = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildFeedProfileLastEatenAt(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	foodID := primitive.NewObjectID()
+	reviews := []models.Review{
+		{CreatedAt: now.Add(-48 * time.Hour), Foods: []models.ReviewedFoodItem{{FoodID: foodID}}},
+		{CreatedAt: now.Add(-2 * time.Hour), Foods: []models.ReviewedFoodItem{{FoodID: foodID}}},
+		{CreatedAt: now.Add(-24 * time.Hour), Foods: []models.ReviewedFoodItem{{FoodID: foodID}}},
+	}
+
+	profile := buildFeedProfile(nil, reviews, nil, now)
+	if got := profile.lastEatenAt[foodID]; !got.Equal(now.Add(-2 * time.Hour)) {
+		t.Errorf("lastEatenAt = %v, want the most recent review", got)
+	}
+}
+
+func TestScoreFeedFood(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	liked := &models.StandardFood{ID: primitive.NewObjectID()}
+	plain := &models.StandardFood{ID: primitive.NewObjectID()}
+	mixed := &models.StandardFood{ID: primitive.NewObjectID(), Categories: []string{"면", "국밥", "분식"}}
+	eaten := &models.StandardFood{ID: primitive.NewObjectID()}
+
+	eatenProfile := func(age time.Duration) feedProfile {
+		profile := emptyFeedProfile()
+		profile.lastEatenAt[eaten.ID] = now.Add(-age)
+		return profile
+	}
+
+	likedProfile := emptyFeedProfile()
+	likedProfile.likedFoodIDs[liked.ID] = true
+
+	mixedProfile := emptyFeedProfile()
+	mixedProfile.categoryAffinity["면"] = 0.2
+	mixedProfile.categoryAffinity["국밥"] = -0.5
+	mixedProfile.categoryAffinity["분식"] = 0.3
+
+	tests := []struct {
+		name    string
+		food    *models.StandardFood
+		profile feedProfile
+		want    float64
+		reasons []models.FeedReason
+	}{
+		{"no signal", plain, likedProfile, 0, nil},
+		{"liked bonus", liked, likedProfile, feedLikedWeight, []models.FeedReason{
+			{Code: feedReasonLiked, Score: feedLikedWeight},
+		}},
+		// 가장 강하게 반응한 카테고리(절댓값 기준)는 싫어하는 국밥
+		{"mixed categories pick the strongest affinity", mixed, mixedProfile, feedCategoryWeight * -0.5, []models.FeedReason{
+			{Code: feedReasonCategory, Score: feedCategoryWeight * -0.5, Detail: "국밥"},
+		}},
+		{"eaten just now gets the full penalty", eaten, eatenProfile(0), -feedRecentlyEatenWeight, []models.FeedReason{
+			{Code: feedReasonRecentlyEaten, Score: -feedRecentlyEatenWeight},
+		}},
+		{"eaten 1.5 days ago gets half the penalty", eaten, eatenProfile(36 * time.Hour), -feedRecentlyEatenWeight / 2, []models.FeedReason{
+			{Code: feedReasonRecentlyEaten, Score: -feedRecentlyEatenWeight / 2},
+		}},
+		{"eaten just over 3 days ago has no penalty", eaten, eatenProfile(feedRecentlyEatenDays*day + time.Minute), 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reasons := scoreFeedFood(tt.food, tt.profile, now)
+			if !approxEqual(got, tt.want) {
+				t.Errorf("score = %v, want %v", got, tt.want)
+			}
+			if len(reasons) != len(tt.reasons) {
+				t.Fatalf("reasons = %+v, want %+v", reasons, tt.reasons)
+			}
+			for i, reason := range reasons {
+				want := tt.reasons[i]
+				if reason.Code != want.Code || reason.Detail != want.Detail || !approxEqual(reason.Score, want.Score) {
+					t.Errorf("reasons[%d] = %+v, want %+v", i, reason, want)
+				}
+			}
+		})
+	}
+}
+
+func TestScoreFeedFoodCombinesSignals(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	food := &models.StandardFood{ID: primitive.NewObjectID(), Categories: []string{"국밥"}}
+
+	profile := emptyFeedProfile()
+	profile.likedFoodIDs[food.ID] = true
+	profile.categoryAffinity["국밥"] = 0.5
+	profile.lastEatenAt[food.ID] = now
+
+	got, reasons := scoreFeedFood(food, profile, now)
+	want := feedLikedWeight + feedCategoryWeight*0.5 - feedRecentlyEatenWeight
+	if !approxEqual(got, want) {
+		t.Errorf("score = %v, want %v", got, want)
+	}
+
+	sum := 0.0
+	for _, reason := range reasons {
+		sum += reason.Score
+	}
+	if !approxEqual(sum, got) {
+		t.Errorf("reason scores sum to %v, want the total score %v", sum, got)
+	}
+}
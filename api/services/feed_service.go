@@ -0,0 +1,231 @@
+// api/services/feed_service.go
+
+package services
+
+import (
+	"bytes"
+	"log"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/seojoonrp/bapddang-server/api/repositories"
+	"github.com/seojoonrp/bapddang-server/models"
+	"github.com/seojoonrp/bapddang-server/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 개인화 점수가 같은 음식들 사이에서 매번 같은 순서만 나오지 않도록 섞는 정도
+const feedJitter = 0.3
+
+// 끼니별 리뷰 통계는 자주 바뀌지 않으므로 주기적으로 다시 집계함
+const mealTimeStatsRefreshInterval = 30 * time.Minute
+
+type FeedService interface {
+	GetMainFeed(query models.MainFeedQuery, user *models.User) (*models.MainFeedPage, error)
+
+	StartMealTimeStatsSync()
+}
+
+type feedService struct {
+	foodService FoodService
+	reviewRepo  repositories.ReviewRepository
+
+	mealTimeStats     *mealTimeStats
+	mealTimeStatsLock sync.RWMutex
+}
+
+func NewFeedService(foodService FoodService, reviewRepo repositories.ReviewRepository) FeedService {
+	return &feedService{
+		foodService: foodService,
+		reviewRepo:  reviewRepo,
+	}
+}
+
+// user가 nil이면 (로그인하지 않은 요청) seed로 섞은 순서대로, 로그인한 유저는 개인화 점수 순으로 정렬함
+// 끼니 추천 모드면 끼니 점수를 더해서 정렬함 (로그인하지 않았으면 전체 유저 기준 점수만)
+// 정렬된 후보에서 세션에 아직 나오지 않은 음식을 카테고리가 겹치지 않게 골라 한 페이지를 만듦
+func (s *feedService) GetMainFeed(query models.MainFeedQuery, user *models.User) (*models.MainFeedPage, error) {
+	var session feedSession
+	if query.Cursor != "" {
+		decoded, err := decodeFeedCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if decoded.foodType != query.Type || decoded.speed != query.Speed {
+			return nil, ErrFeedCursorMismatch
+		}
+		if query.Seed != nil && *query.Seed != decoded.seed {
+			return nil, ErrFeedCursorMismatch
+		}
+		// 자동으로 정한 끼니는 cursor에 담긴 값을 그대로 씀
+		if query.MealTimeMode != (decoded.mealTime != "") || (query.MealTime != "" && query.MealTime != decoded.mealTime) {
+			return nil, ErrFeedCursorMismatch
+		}
+		session = decoded
+	} else {
+		session = feedSession{foodType: query.Type, speed: query.Speed, seed: rand.Int63()}
+		if query.Seed != nil {
+			session.seed = *query.Seed
+		}
+		if query.MealTimeMode {
+			session.mealTime = query.MealTime
+			if session.mealTime == "" {
+				session.mealTime = utils.MealTimeAt(time.Now())
+			}
+		}
+	}
+
+	candidates := s.foodService.GetMainFeedCandidates(session.foodType, session.speed)
+
+	now := time.Now()
+	var profile *feedProfile
+	if user != nil {
+		userProfile, err := s.buildUserFeedProfile(user, now)
+		if err != nil {
+			return nil, err
+		}
+		profile = &userProfile
+	}
+	var stats *mealTimeStats
+	if session.mealTime != "" {
+		s.mealTimeStatsLock.RLock()
+		stats = s.mealTimeStats
+		s.mealTimeStatsLock.RUnlock()
+	}
+
+	type scoredFood struct {
+		food    models.MainFeedFood
+		ranking float64
+	}
+	scoredFoods := make([]scoredFood, 0, len(candidates))
+	for _, food := range candidates {
+		shuffleValue := feedShuffleValue(session.seed, food.ID)
+		if profile == nil && session.mealTime == "" {
+			scoredFoods = append(scoredFoods, scoredFood{
+				food:    models.MainFeedFood{StandardFood: food},
+				ranking: shuffleValue,
+			})
+			continue
+		}
+
+		score, reasons := 0.0, make([]models.FeedReason, 0)
+		if profile != nil {
+			score, reasons = scoreFeedFood(food, *profile, now)
+		}
+		if session.mealTime != "" {
+			mealTimeScore, mealTimeReasons := scoreMealTime(food, session.mealTime, stats, profile)
+			score += mealTimeScore
+			reasons = append(reasons, mealTimeReasons...)
+		}
+		scoredFoods = append(scoredFoods, scoredFood{
+			food:    models.MainFeedFood{StandardFood: food, Score: score, Reasons: reasons},
+			ranking: score + shuffleValue*feedJitter,
+		})
+	}
+	// 후보 목록은 캐시의 map 순서를 따르므로, 같은 값이면 ID 순으로 정렬해 항상 같은 순서가 되게 함
+	sort.Slice(scoredFoods, func(i, j int) bool {
+		if scoredFoods[i].ranking != scoredFoods[j].ranking {
+			return scoredFoods[i].ranking > scoredFoods[j].ranking
+		}
+		return bytes.Compare(scoredFoods[i].food.ID[:], scoredFoods[j].food.ID[:]) < 0
+	})
+
+	sortedFoods := make([]*models.StandardFood, 0, len(scoredFoods))
+	feedFoods := make(map[primitive.ObjectID]models.MainFeedFood, len(scoredFoods))
+	for _, scored := range scoredFoods {
+		sortedFoods = append(sortedFoods, scored.food.StandardFood)
+		feedFoods[scored.food.ID] = scored.food
+	}
+
+	// 현재 라운드에서 이미 나온 음식의 카테고리를 알아야 하므로, 그 사이 보관 처리된 음식도 캐시에서 찾음
+	seenFoods := make(map[primitive.ObjectID]*models.StandardFood)
+	if roundFoodIDs := session.seenFoodIDs[session.roundStart:]; len(roundFoodIDs) > 0 {
+		roundFoods, err := s.foodService.GetStandardFoodsByIDs(roundFoodIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, food := range roundFoods {
+			seenFoods[food.ID] = food
+		}
+	}
+
+	selectedFoods, next, hasMore := selectFeedPage(sortedFoods, session, seenFoods, query.Count)
+
+	page := &models.MainFeedPage{
+		Foods:    make([]models.MainFeedFood, 0, len(selectedFoods)),
+		Seed:     session.seed,
+		MealTime: session.mealTime,
+	}
+	for _, food := range selectedFoods {
+		page.Foods = append(page.Foods, feedFoods[food.ID])
+	}
+	if hasMore {
+		page.NextCursor = encodeFeedCursor(next)
+	}
+
+	log.Printf("Selected %d main feed foods (personalized: %t, meal time: %q, seen: %d)", len(page.Foods), user != nil, session.mealTime, len(next.seenFoodIDs))
+	return page, nil
+}
+
+func (s *feedService) buildUserFeedProfile(user *models.User, now time.Time) (feedProfile, error) {
+	reviews, err := s.reviewRepo.FindRecentByUserID(user.ID, now.Add(-feedProfileWindow), feedProfileMaxReviews)
+	if err != nil {
+		return feedProfile{}, err
+	}
+
+	foodIDs := make([]primitive.ObjectID, 0)
+	for _, review := range reviews {
+		for _, item := range review.Foods {
+			if item.FoodType == "standard" {
+				foodIDs = append(foodIDs, item.FoodID)
+			}
+		}
+	}
+
+	foods := make(map[primitive.ObjectID]*models.StandardFood)
+	if len(foodIDs) > 0 {
+		reviewedFoods, err := s.foodService.GetStandardFoodsByIDs(foodIDs)
+		if err != nil {
+			return feedProfile{}, err
+		}
+		for _, food := range reviewedFoods {
+			foods[food.ID] = food
+		}
+	}
+
+	return buildFeedProfile(user.LikedFoodIDs, reviews, foods, now), nil
+}
+
+func (s *feedService) StartMealTimeStatsSync() {
+	if err := s.refreshMealTimeStats(); err != nil {
+		log.Printf("WARNING: Failed to load meal time stats: %v", err)
+	}
+
+	go func() {
+		ticker := time.NewTicker(mealTimeStatsRefreshInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := s.refreshMealTimeStats(); err != nil {
+				log.Printf("Failed to refresh meal time stats: %v", err)
+			}
+		}
+	}()
+}
+
+func (s *feedService) refreshMealTimeStats() error {
+	counts, err := s.reviewRepo.AggregateFoodMealTimeCounts()
+	if err != nil {
+		return err
+	}
+	stats := buildMealTimeStats(counts)
+
+	s.mealTimeStatsLock.Lock()
+	s.mealTimeStats = stats
+	s.mealTimeStatsLock.Unlock()
+
+	log.Printf("Loaded meal time stats for %d foods", len(stats.foodTotals))
+	return nil
+}
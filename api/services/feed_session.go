@@ -0,0 +1,187 @@
+// api/services/feed_session.go
+
+package services
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"slices"
+
+	"github.com/seojoonrp/bapddang-server/models"
+	"github.com/seojoonrp/bapddang-server/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 메인 피드 세션 (무한 스크롤)
+// 서버가 여러 대여도 이어받을 수 있도록 세션 상태는 서버에 두지 않고 cursor에 모두 담음
+// cursor = seed + 끼니 + 지금까지 보여준 음식 ID들 + 현재 라운드 시작 위치
+
+const (
+	feedCursorVersion = 1
+	feedCursorHeader  = 12 // version(1) + type/speed/끼니(1) + seed(8) + roundStart(2)
+
+	// cursor가 URL에 들어가므로 한 세션에서 보여줄 수 있는 음식 수를 제한함 (300개면 약 5KB)
+	feedSessionMaxFoods = 300
+)
+
+var (
+	ErrInvalidFeedCursor  = errors.New("invalid cursor")
+	ErrFeedCursorMismatch = errors.New("cursor does not match feed")
+)
+
+type feedSession struct {
+	foodType string
+	speed    string
+	seed     int64
+	// 끼니 추천 모드일 때의 끼니. 세션 도중 시간대가 바뀌어도 처음 정한 끼니를 유지함
+	mealTime string
+	// 보여준 순서대로의 음식 ID
+	seenFoodIDs []primitive.ObjectID
+	// seenFoodIDs[roundStart:]가 현재 라운드. 같은 라운드 안에서는 카테고리가 겹치지 않음
+	roundStart int
+}
+
+func encodeFeedCursor(session feedSession) string {
+	buf := make([]byte, feedCursorHeader, feedCursorHeader+len(session.seenFoodIDs)*12)
+	buf[0] = feedCursorVersion
+	if session.foodType == "dessert" {
+		buf[1] |= 1
+	}
+	if session.speed == "slow" {
+		buf[1] |= 2
+	}
+	// 3~5번째 비트: utils.MealTimes에서의 위치 + 1 (0이면 끼니 추천 모드가 아님)
+	if index := slices.Index(utils.MealTimes, session.mealTime); index >= 0 {
+		buf[1] |= byte(index+1) << 2
+	}
+	binary.BigEndian.PutUint64(buf[2:10], uint64(session.seed))
+	binary.BigEndian.PutUint16(buf[10:12], uint16(session.roundStart))
+	for _, foodID := range session.seenFoodIDs {
+		buf = append(buf, foodID[:]...)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+func decodeFeedCursor(cursor string) (feedSession, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(buf) < feedCursorHeader || buf[0] != feedCursorVersion || (len(buf)-feedCursorHeader)%12 != 0 {
+		return feedSession{}, ErrInvalidFeedCursor
+	}
+
+	session := feedSession{
+		foodType:   "meal",
+		speed:      "fast",
+		seed:       int64(binary.BigEndian.Uint64(buf[2:10])),
+		roundStart: int(binary.BigEndian.Uint16(buf[10:12])),
+	}
+	if buf[1]&1 != 0 {
+		session.foodType = "dessert"
+	}
+	if buf[1]&2 != 0 {
+		session.speed = "slow"
+	}
+	if index := int(buf[1]>>2) & 7; index > 0 {
+		if index > len(utils.MealTimes) {
+			return feedSession{}, ErrInvalidFeedCursor
+		}
+		session.mealTime = utils.MealTimes[index-1]
+	}
+	for offset := feedCursorHeader; offset < len(buf); offset += 12 {
+		var foodID primitive.ObjectID
+		copy(foodID[:], buf[offset:offset+12])
+		session.seenFoodIDs = append(session.seenFoodIDs, foodID)
+	}
+	if session.roundStart > len(session.seenFoodIDs) {
+		return feedSession{}, ErrInvalidFeedCursor
+	}
+
+	return session, nil
+}
+
+// seed와 음식 ID로 정해지는 [0, 1) 사이의 값
+// 후보 목록 순서나 다른 음식의 추가/삭제와 상관없이 같은 seed면 같은 음식은 항상 같은 값을 가짐
+func feedShuffleValue(seed int64, foodID primitive.ObjectID) float64 {
+	h := fnv.New64a()
+	h.Write(foodID[:])
+
+	// splitmix64: 비슷한 ObjectID끼리도 값이 고르게 퍼지도록 섞음
+	x := h.Sum64() ^ uint64(seed)
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	x ^= x >> 31
+
+	return float64(x>>11) / (1 << 53)
+}
+
+// 정렬된 후보에서 아직 보여주지 않은 음식을 foodCount개 고르고, 다음 세션 상태를 돌려줌
+// 같은 라운드 안에서는 카테고리가 겹치지 않게 고르고 (카테고리가 없는 음식은 항상 고를 수 있음),
+// 남은 음식이 모두 이미 나온 카테고리뿐이면 새 라운드를 시작함
+// seenFoods는 현재 라운드에서 이미 보여준 음식들 (카테고리 확인용)
+// 마지막 반환값은 다음 페이지에 보여줄 음식이 남아 있는지 여부
+func selectFeedPage(ordered []*models.StandardFood, session feedSession, seenFoods map[primitive.ObjectID]*models.StandardFood, foodCount int) ([]*models.StandardFood, feedSession, bool) {
+	seen := make(map[primitive.ObjectID]bool, len(session.seenFoodIDs))
+	for _, foodID := range session.seenFoodIDs {
+		seen[foodID] = true
+	}
+	usedCategories := make(map[string]bool)
+	for _, foodID := range session.seenFoodIDs[session.roundStart:] {
+		if food, exists := seenFoods[foodID]; exists {
+			for _, category := range food.Categories {
+				usedCategories[category] = true
+			}
+		}
+	}
+
+	remaining := 0
+	for _, food := range ordered {
+		if !seen[food.ID] {
+			remaining++
+		}
+	}
+	foodCount = min(foodCount, feedSessionMaxFoods-len(session.seenFoodIDs))
+
+	next := session
+	next.seenFoodIDs = append([]primitive.ObjectID{}, session.seenFoodIDs...)
+	resultList := make([]*models.StandardFood, 0, max(foodCount, 0))
+
+	for len(resultList) < foodCount && remaining > 0 {
+		for _, food := range ordered {
+			if len(resultList) >= foodCount {
+				break
+			}
+			if seen[food.ID] {
+				continue
+			}
+
+			uniqueCategory := true
+			for _, category := range food.Categories {
+				if usedCategories[category] {
+					uniqueCategory = false
+					break
+				}
+			}
+			if !uniqueCategory {
+				continue
+			}
+
+			resultList = append(resultList, food)
+			seen[food.ID] = true
+			next.seenFoodIDs = append(next.seenFoodIDs, food.ID)
+			remaining--
+			for _, category := range food.Categories {
+				usedCategories[category] = true
+			}
+		}
+
+		if len(resultList) < foodCount && remaining > 0 {
+			next.roundStart = len(next.seenFoodIDs)
+			usedCategories = make(map[string]bool)
+		}
+	}
+
+	hasMore := remaining > 0 && len(next.seenFoodIDs) < feedSessionMaxFoods
+	return resultList, next, hasMore
+}
@@ -0,0 +1,746 @@
+// api/services/food_admin_service.go
+
+// 관리자용 standard 음식 수정/보관/삭제
+// 음식이 바뀌면 캐시와, 그 음식을 참조하는 유저 좋아요 및 리뷰도 함께 정리함
+
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/seojoonrp/bapddang-server/api/repositories"
+	"github.com/seojoonrp/bapddang-server/models"
+	"github.com/seojoonrp/bapddang-server/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// 음식 생성/수정/가져오기에서 쓰는 검증 에러 (핸들러에서 errors.Is로 구분)
+var (
+	ErrFoodAlreadyExists   = errors.New("food already exists")
+	ErrAliasInUse          = errors.New("alias already in use")
+	ErrInvalidAlias        = errors.New("invalid alias")
+	ErrInvalidFoodName     = errors.New("invalid food name")
+	ErrInvalidSpeed        = errors.New("invalid speed")
+	ErrInvalidFoodType     = errors.New("invalid food type")
+	ErrInvalidEnglishName  = errors.New("invalid english name")
+	ErrInvalidMergeRequest = errors.New("invalid merge request")
+)
+
+// 잘못된 입력이라 400으로 응답해야 하는 에러인지
+func IsFoodValidationError(err error) bool {
+	return errors.Is(err, ErrInvalidAlias) || errors.Is(err, ErrInvalidFoodName) || errors.Is(err, ErrInvalidSpeed) ||
+		errors.Is(err, ErrInvalidFoodType) || errors.Is(err, ErrInvalidEnglishName)
+}
+
+type FoodAdminService interface {
+	UpdateStandardFood(foodID primitive.ObjectID, input models.UpdateStandardFoodInput) (*models.StandardFood, error)
+	AddStandardFoodAliases(foodID primitive.ObjectID, aliases []string) (*models.StandardFood, error)
+	RemoveStandardFoodAlias(foodID primitive.ObjectID, alias string) (*models.StandardFood, error)
+	SetStandardFoodArchived(foodID primitive.ObjectID, archived bool) (*models.StandardFood, error)
+	DeleteStandardFood(foodID primitive.ObjectID) (*models.StandardFoodDeletionResult, error)
+
+	ListPopularCustomFoods(limit int, threshold float64) ([]models.CustomFoodCluster, error)
+	PromoteCustomFood(customFoodID primitive.ObjectID, input models.PromoteCustomFoodInput) (*models.CustomFoodPromotionResult, error)
+
+	MergeFoods(input models.MergeFoodsInput) (*models.MergeFoodsResult, error)
+	MergeDuplicateCustomFoods(dryRun bool) (*models.CustomFoodDedupeReport, error)
+}
+
+type foodAdminService struct {
+	foodRepo         repositories.FoodRepository
+	foodRedirectRepo repositories.FoodRedirectRepository
+	reviewRepo       repositories.ReviewRepository
+	userRepo         repositories.UserRepository
+	foodService      FoodService
+	txManager        repositories.TransactionManager
+}
+
+// foodService가 nil이면 캐시 갱신을 건너뜀 (캐시를 들고 있지 않은 CLI에서 사용, 서버 캐시는 change stream으로 반영됨)
+func NewFoodAdminService(foodRepo repositories.FoodRepository, foodRedirectRepo repositories.FoodRedirectRepository, reviewRepo repositories.ReviewRepository, userRepo repositories.UserRepository, foodService FoodService, txManager repositories.TransactionManager) FoodAdminService {
+	return &foodAdminService{
+		foodRepo:         foodRepo,
+		foodRedirectRepo: foodRedirectRepo,
+		reviewRepo:       reviewRepo,
+		userRepo:         userRepo,
+		foodService:      foodService,
+		txManager:        txManager,
+	}
+}
+
+func validateFoodAttributes(name, speed, foodType string) error {
+	if strings.TrimSpace(name) == "" {
+		return ErrInvalidFoodName
+	}
+	if speed != "fast" && speed != "slow" {
+		return ErrInvalidSpeed
+	}
+	if foodType != "meal" && foodType != "dessert" {
+		return ErrInvalidFoodType
+	}
+	return nil
+}
+
+// 영문 이름은 비워 둘 수 있지만, 채운다면 로마자로 매칭할 수 있도록 영문자가 있어야 함
+func validateEnglishName(englishName string) error {
+	if englishName != "" && !utils.ContainsLatin(englishName) {
+		return ErrInvalidEnglishName
+	}
+	return nil
+}
+
+// input에 들어 있는 필드만 검증해서 바꿈 (나머지 필드는 DB 값을 그대로 둠)
+func (s *foodAdminService) UpdateStandardFood(foodID primitive.ObjectID, input models.UpdateStandardFoodInput) (*models.StandardFood, error) {
+	food, err := s.foodRepo.FindStandardFoodByID(foodID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		// 생성/가져오기와 같이 정규화한 이름으로 다른 음식의 이름/별칭과 겹치는지 확인
+		owners, err := s.standardFoodNameOwners()
+		if err != nil {
+			return nil, err
+		}
+		if owners.takenByOther(food.ID, name) {
+			return nil, ErrFoodAlreadyExists
+		}
+		input.Name = &name
+		food.Name = name
+	}
+	if input.Speed != nil {
+		food.Speed = *input.Speed
+	}
+	if input.Type != nil {
+		food.Type = *input.Type
+	}
+	if input.EnglishName != nil {
+		englishName := strings.TrimSpace(*input.EnglishName)
+		input.EnglishName = &englishName
+		food.EnglishName = englishName
+	}
+
+	if err := validateFoodAttributes(food.Name, food.Speed, food.Type); err != nil {
+		return nil, err
+	}
+	if err := validateEnglishName(food.EnglishName); err != nil {
+		return nil, err
+	}
+
+	if input.Aliases != nil {
+		aliases, err := s.validateAliases(food, *input.Aliases)
+		if err != nil {
+			return nil, err
+		}
+		input.Aliases = &aliases
+	}
+
+	updated, err := s.foodRepo.UpdateStandardFood(foodID, input)
+	if err != nil {
+		return nil, err
+	}
+	if s.foodService != nil {
+		s.foodService.UpsertStandardFoodCache(updated)
+	}
+
+	return updated, nil
+}
+
+func (s *foodAdminService) AddStandardFoodAliases(foodID primitive.ObjectID, aliases []string) (*models.StandardFood, error) {
+	food, err := s.foodRepo.FindStandardFoodByID(foodID)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases, err = s.validateAliases(food, aliases)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.foodRepo.AddStandardFoodAliases(foodID, aliases); err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]bool, len(food.Aliases))
+	for _, alias := range food.Aliases {
+		existing[alias] = true
+	}
+	for _, alias := range aliases {
+		if !existing[alias] {
+			food.Aliases = append(food.Aliases, alias)
+		}
+	}
+	if s.foodService != nil {
+		s.foodService.UpsertStandardFoodCache(food)
+	}
+
+	return food, nil
+}
+
+func (s *foodAdminService) RemoveStandardFoodAlias(foodID primitive.ObjectID, alias string) (*models.StandardFood, error) {
+	food, err := s.foodRepo.FindStandardFoodByID(foodID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.foodRepo.RemoveStandardFoodAlias(foodID, alias); err != nil {
+		return nil, err
+	}
+
+	aliases := make([]string, 0, len(food.Aliases))
+	for _, existing := range food.Aliases {
+		if existing != alias {
+			aliases = append(aliases, existing)
+		}
+	}
+	food.Aliases = aliases
+	if s.foodService != nil {
+		s.foodService.UpsertStandardFoodCache(food)
+	}
+
+	return food, nil
+}
+
+// 별칭을 다듬고 중복을 없앰
+// 정규화했을 때 자기 이름과 같거나 다른 standard 음식의 이름/별칭과 겹치는 별칭은 허용하지 않음
+func (s *foodAdminService) validateAliases(food *models.StandardFood, aliases []string) ([]string, error) {
+	owners, err := s.standardFoodNameOwners()
+	if err != nil {
+		return nil, err
+	}
+
+	return cleanAliases(food.ID, food.Name, aliases, owners)
+}
+
+// 서버에서는 캐시의 이름 색인을 쓰고, 캐시가 없는 CLI에서만 DB에서 전체 음식을 읽음
+func (s *foodAdminService) standardFoodNameOwners() (foodNameOwners, error) {
+	if s.foodService != nil {
+		return s.foodService.standardFoodNameOwners(), nil
+	}
+
+	standardFoods, err := s.foodRepo.GetAllStandardFoods()
+	if err != nil {
+		return nil, err
+	}
+	return newFoodNameOwners(standardFoods), nil
+}
+
+// 정규화한 이름/별칭 → 그 이름을 쓰고 있는 standard 음식 ID
+type foodNameOwners map[string]primitive.ObjectID
+
+func newFoodNameOwners(foods []*models.StandardFood) foodNameOwners {
+	owners := make(foodNameOwners)
+	for _, food := range foods {
+		owners.claim(food.ID, food.Name, food.Aliases)
+	}
+	return owners
+}
+
+func (o foodNameOwners) claim(foodID primitive.ObjectID, name string, aliases []string) {
+	for _, value := range append([]string{name}, aliases...) {
+		normalized := utils.NormalizeBasic(value)
+		if _, taken := o[normalized]; !taken && normalized != "" {
+			o[normalized] = foodID
+		}
+	}
+}
+
+// foodID 외의 음식이 쓰고 있는지 확인
+func (o foodNameOwners) takenByOther(foodID primitive.ObjectID, value string) bool {
+	owner, taken := o[utils.NormalizeBasic(value)]
+	return taken && owner != foodID
+}
+
+// validateAliases와 같은 규칙을 owners에 대해 적용
+// 음식 생성/가져오기처럼 아직 DB에 없는 음식의 별칭을 검사할 때도 사용함
+func cleanAliases(foodID primitive.ObjectID, name string, aliases []string, owners foodNameOwners) ([]string, error) {
+	ownName := utils.NormalizeBasic(name)
+	seen := make(map[string]bool)
+	result := make([]string, 0, len(aliases))
+
+	for _, alias := range aliases {
+		alias = strings.TrimSpace(alias)
+		normalized := utils.NormalizeBasic(alias)
+		if normalized == "" {
+			return nil, ErrInvalidAlias
+		}
+		if owners.takenByOther(foodID, alias) {
+			return nil, ErrAliasInUse
+		}
+		if normalized == ownName || seen[normalized] {
+			continue
+		}
+		seen[normalized] = true
+		result = append(result, alias)
+	}
+
+	return result, nil
+}
+
+// 보관된 음식은 메인 피드와 음식 검증에서 제외되지만, 기존 리뷰와 좋아요는 그대로 유지됨
+func (s *foodAdminService) SetStandardFoodArchived(foodID primitive.ObjectID, archived bool) (*models.StandardFood, error) {
+	food, err := s.foodRepo.FindStandardFoodByID(foodID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.foodRepo.SetStandardFoodArchived(foodID, archived); err != nil {
+		return nil, err
+	}
+	food.Archived = archived
+	if s.foodService != nil {
+		s.foodService.UpsertStandardFoodCache(food)
+	}
+
+	return food, nil
+}
+
+// standard 음식을 완전히 삭제
+// 유저들의 좋아요에서 제거하고, 이 음식을 참조하던 리뷰는 같은 이름의 custom 음식을 가리키도록 바꿔 리뷰 내용이 깨지지 않게 함
+func (s *foodAdminService) DeleteStandardFood(foodID primitive.ObjectID) (*models.StandardFoodDeletionResult, error) {
+	var result *models.StandardFoodDeletionResult
+
+	err := s.txManager.WithTransaction(func(ctx context.Context) error {
+		deletedFood, err := s.foodRepo.DeleteStandardFood(ctx, foodID)
+		if err != nil {
+			return err
+		}
+
+		removedLikes, err := s.userRepo.RemoveLikedFoodFromAll(ctx, foodID)
+		if err != nil {
+			return err
+		}
+
+		result = &models.StandardFoodDeletionResult{
+			DeletedFood:  deletedFood,
+			RemovedLikes: removedLikes,
+		}
+
+		reviewerIDs, err := s.reviewRepo.FindUserIDsByFoodID(ctx, foodID)
+		if err != nil {
+			return err
+		}
+		if len(reviewerIDs) == 0 {
+			return nil
+		}
+
+		replacement, _, err := s.foodRepo.FindOrCreateCustomFood(ctx, deletedFood.Name, reviewerIDs)
+		if err != nil {
+			return err
+		}
+
+		updatedReviews, err := s.reviewRepo.ReplaceFoodInReviews(ctx, foodID, models.ReviewedFoodItem{
+			FoodID:   replacement.ID,
+			FoodType: "custom",
+		})
+		if err != nil {
+			return err
+		}
+
+		result.ReplacementFood = replacement
+		result.UpdatedReviews = updatedReviews
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if s.foodService != nil {
+		s.foodService.RemoveStandardFoodCache(foodID)
+		if result.ReplacementFood != nil {
+			s.foodService.UpsertCustomFoodCache(result.ReplacementFood)
+		}
+	}
+
+	return result, nil
+}
+
+// 사용자 수 상위 custom 음식을 utils.Score로 묶어서 보여줌
+// 순위 순서대로 아직 묶이지 않은 음식을 대표로 삼고, 대표와 threshold 이상 비슷한 음식을 같은 묶음에 넣음
+func (s *foodAdminService) ListPopularCustomFoods(limit int, threshold float64) ([]models.CustomFoodCluster, error) {
+	rankings, err := s.foodRepo.FindCustomFoodsByUserCount(limit)
+	if err != nil {
+		return nil, err
+	}
+	standardFoods, err := s.foodRepo.GetAllStandardFoods()
+	if err != nil {
+		return nil, err
+	}
+
+	clusters := make([]models.CustomFoodCluster, 0)
+	clustered := make([]bool, len(rankings))
+
+	for i, representative := range rankings {
+		if clustered[i] {
+			continue
+		}
+		clustered[i] = true
+
+		cluster := models.CustomFoodCluster{
+			Representative:       representative,
+			Members:              []models.CustomFoodRanking{representative},
+			TotalUserCount:       representative.UserCount,
+			SimilarStandardFoods: []models.ValidationOutput{},
+		}
+
+		for j := i + 1; j < len(rankings); j++ {
+			if clustered[j] || utils.Score(representative.Name, rankings[j].Name) < threshold {
+				continue
+			}
+			clustered[j] = true
+			cluster.Members = append(cluster.Members, rankings[j])
+			cluster.TotalUserCount += rankings[j].UserCount
+		}
+
+		for _, food := range standardFoods {
+			if standardFoodScore(representative.Name, food) >= threshold {
+				cluster.SimilarStandardFoods = append(cluster.SimilarStandardFoods, models.ValidationOutput{
+					ID:   food.ID,
+					Name: food.Name,
+					Type: "standard",
+				})
+			}
+		}
+
+		clusters = append(clusters, cluster)
+	}
+
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return clusters[i].TotalUserCount > clusters[j].TotalUserCount
+	})
+
+	return clusters, nil
+}
+
+// custom 음식을 standard 음식으로 승격
+// 리뷰의 해당 음식 항목을 새 standard 음식으로 바꾸고, 그 리뷰들로부터 review_count/total_rating을 채운 뒤 custom 음식은 삭제함
+// 지운 custom 음식 ID는 food_redirects에 기록해 새 standard 음식으로 조회되게 함
+func (s *foodAdminService) PromoteCustomFood(customFoodID primitive.ObjectID, input models.PromoteCustomFoodInput) (*models.CustomFoodPromotionResult, error) {
+	customFood, err := s.foodRepo.FindCustomFoodByID(customFoodID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		name = customFood.Name
+	}
+	if err := validateFoodAttributes(name, input.Speed, input.Type); err != nil {
+		return nil, err
+	}
+	englishName := strings.TrimSpace(input.EnglishName)
+	if err := validateEnglishName(englishName); err != nil {
+		return nil, err
+	}
+
+	// 생성/가져오기와 같이 정규화한 이름으로 다른 standard 음식과 겹치는지 확인
+	owners, err := s.standardFoodNameOwners()
+	if err != nil {
+		return nil, err
+	}
+	if _, taken := owners[utils.NormalizeBasic(name)]; taken {
+		return nil, ErrFoodAlreadyExists
+	}
+
+	// 이름을 바꿔 승격하면 유저들이 쓰던 원래 이름은 별칭으로 남겨 계속 매칭되게 함
+	// (새 이름과 정규화해서 같으면 cleanAliases가 빼고, 다른 음식이 쓰는 이름이면 ErrAliasInUse)
+	newFoodID := primitive.NewObjectID()
+	aliases, err := cleanAliases(newFoodID, name, []string{customFood.Name}, owners)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := input.Categories
+	if categories == nil {
+		categories = []string{}
+	}
+	newFood := &models.StandardFood{
+		ID:          newFoodID,
+		Name:        name,
+		ImageURL:    input.ImageURL,
+		Speed:       input.Speed,
+		Type:        input.Type,
+		Categories:  categories,
+		Aliases:     aliases,
+		EnglishName: englishName,
+	}
+
+	var updatedReviews int64
+	err = s.txManager.WithTransaction(func(ctx context.Context) error {
+		if err := s.foodRepo.SaveStandardFood(ctx, newFood); err != nil {
+			return err
+		}
+
+		replacedReviews, err := s.reviewRepo.ReplaceFoodInReviews(ctx, customFood.ID, models.ReviewedFoodItem{
+			FoodID:   newFood.ID,
+			FoodType: "standard",
+		})
+		if err != nil {
+			return err
+		}
+		updatedReviews = replacedReviews
+
+		stats, err := s.reviewRepo.AggregateFoodReviewStatsByFoodID(ctx, newFood.ID)
+		if err != nil {
+			return err
+		}
+		err = s.foodRepo.UpdateReviewStats(ctx, []models.ReviewStatsDiff{{
+			FoodID:      newFood.ID,
+			ReviewCount: stats.ReviewCount,
+			TotalRating: stats.TotalRating,
+		}})
+		if err != nil {
+			return err
+		}
+		newFood.ReviewCount = stats.ReviewCount
+		newFood.TotalRating = stats.TotalRating
+
+		if err := s.foodRepo.DeleteCustomFood(ctx, customFood.ID); err != nil {
+			return err
+		}
+
+		// MergeFoods와 같이 지워진 custom 음식 ID로도 계속 조회되도록 redirect를 남김
+		if err := s.foodRedirectRepo.Retarget(ctx, []primitive.ObjectID{customFood.ID}, newFood.ID, "standard"); err != nil {
+			return err
+		}
+		return s.foodRedirectRepo.Save(ctx, []models.FoodRedirect{{
+			FromID:    customFood.ID,
+			ToID:      newFood.ID,
+			ToType:    "standard",
+			CreatedAt: time.Now(),
+		}})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if s.foodService != nil {
+		s.foodService.RemoveCustomFoodCache(customFood.ID)
+		s.foodService.UpsertStandardFoodCache(newFood)
+	}
+
+	return &models.CustomFoodPromotionResult{
+		StandardFood:   newFood,
+		UpdatedReviews: updatedReviews,
+	}, nil
+}
+
+// standard/custom 어느 쪽 ID인지 모르는 음식을 찾음
+func (s *foodAdminService) findAnyFood(foodID primitive.ObjectID) (*models.ValidationOutput, *models.CustomFood, error) {
+	standardFood, err := s.foodRepo.FindStandardFoodByID(foodID)
+	if err == nil {
+		return &models.ValidationOutput{ID: standardFood.ID, Name: standardFood.Name, Type: "standard"}, nil, nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return nil, nil, err
+	}
+
+	customFood, err := s.foodRepo.FindCustomFoodByID(foodID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &models.ValidationOutput{ID: customFood.ID, Name: customFood.Name, Type: "custom"}, customFood, nil
+}
+
+// 중복 음식들(victim)을 하나(survivor)로 합침
+// 리뷰의 음식 항목과 좋아요, custom 음식 사용자 목록을 survivor로 옮기고 victim은 삭제함
+// victim ID는 food_redirects에 기록해 GetStandardFoodByID에서 계속 조회되도록 함
+func (s *foodAdminService) MergeFoods(input models.MergeFoodsInput) (*models.MergeFoodsResult, error) {
+	survivorID, err := primitive.ObjectIDFromHex(input.SurvivorID)
+	if err != nil {
+		return nil, ErrInvalidMergeRequest
+	}
+
+	victimIDs := make([]primitive.ObjectID, 0, len(input.VictimIDs))
+	seen := map[primitive.ObjectID]bool{survivorID: true}
+	for _, idStr := range input.VictimIDs {
+		victimID, err := primitive.ObjectIDFromHex(idStr)
+		if err != nil || seen[victimID] {
+			return nil, ErrInvalidMergeRequest
+		}
+		seen[victimID] = true
+		victimIDs = append(victimIDs, victimID)
+	}
+
+	survivor, _, err := s.findAnyFood(survivorID)
+	if err != nil {
+		return nil, err
+	}
+
+	victims := make([]models.ValidationOutput, 0, len(victimIDs))
+	victimUserIDs := make([]primitive.ObjectID, 0)
+	for _, victimID := range victimIDs {
+		victim, customVictim, err := s.findAnyFood(victimID)
+		if err != nil {
+			return nil, err
+		}
+		victims = append(victims, *victim)
+		if customVictim != nil {
+			victimUserIDs = append(victimUserIDs, customVictim.UsingUserIDs...)
+		}
+	}
+
+	result := &models.MergeFoodsResult{
+		Survivor:    *survivor,
+		MergedFoods: victims,
+	}
+	survivorItem := models.ReviewedFoodItem{FoodID: survivor.ID, FoodType: survivor.Type}
+
+	err = s.txManager.WithTransaction(func(ctx context.Context) error {
+		result.UpdatedReviews = 0
+		result.UpdatedLikes = 0
+		usingUserIDs := append([]primitive.ObjectID{}, victimUserIDs...)
+
+		for _, victim := range victims {
+			if survivor.Type == "custom" {
+				reviewerIDs, err := s.reviewRepo.FindUserIDsByFoodID(ctx, victim.ID)
+				if err != nil {
+					return err
+				}
+				usingUserIDs = append(usingUserIDs, reviewerIDs...)
+			}
+
+			updatedReviews, err := s.reviewRepo.ReplaceFoodInReviews(ctx, victim.ID, survivorItem)
+			if err != nil {
+				return err
+			}
+			result.UpdatedReviews += updatedReviews
+
+			// custom 음식은 좋아요 대상이 아니므로 survivor가 custom이면 좋아요는 지움
+			var updatedLikes int64
+			if survivor.Type == "standard" {
+				updatedLikes, err = s.userRepo.ReplaceLikedFood(ctx, victim.ID, survivor.ID)
+			} else {
+				updatedLikes, err = s.userRepo.RemoveLikedFoodFromAll(ctx, victim.ID)
+			}
+			if err != nil {
+				return err
+			}
+			result.UpdatedLikes += updatedLikes
+
+			if victim.Type == "standard" {
+				_, err = s.foodRepo.DeleteStandardFood(ctx, victim.ID)
+			} else {
+				err = s.foodRepo.DeleteCustomFood(ctx, victim.ID)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if _, err := s.reviewRepo.DedupeFoodInReviews(ctx, survivor.ID); err != nil {
+			return err
+		}
+
+		if survivor.Type == "standard" {
+			// 한 리뷰에 victim과 survivor가 같이 있던 경우가 있어 단순 합산 대신 다시 계산함
+			stats, err := s.reviewRepo.AggregateFoodReviewStatsByFoodID(ctx, survivor.ID)
+			if err != nil {
+				return err
+			}
+			likeCount, err := s.userRepo.CountUsersLikingFood(ctx, survivor.ID)
+			if err != nil {
+				return err
+			}
+			stats.LikeCount = int(likeCount)
+			if err := s.foodRepo.SetFoodStats(ctx, *stats); err != nil {
+				return err
+			}
+		} else {
+			if err := s.foodRepo.AddUsersToCustomFood(ctx, survivor.ID, usingUserIDs); err != nil {
+				return err
+			}
+		}
+
+		if err := s.foodRedirectRepo.Retarget(ctx, victimIDs, survivor.ID, survivor.Type); err != nil {
+			return err
+		}
+		redirects := make([]models.FoodRedirect, 0, len(victims))
+		for _, victim := range victims {
+			redirects = append(redirects, models.FoodRedirect{
+				FromID:    victim.ID,
+				ToID:      survivor.ID,
+				ToType:    survivor.Type,
+				CreatedAt: time.Now(),
+			})
+		}
+		return s.foodRedirectRepo.Save(ctx, redirects)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if s.foodService == nil {
+		return result, nil
+	}
+	for _, victim := range victims {
+		if victim.Type == "standard" {
+			s.foodService.RemoveStandardFoodCache(victim.ID)
+		} else {
+			s.foodService.RemoveCustomFoodCache(victim.ID)
+		}
+	}
+	if survivor.Type == "standard" {
+		if food, err := s.foodRepo.FindStandardFoodByID(survivor.ID); err == nil {
+			s.foodService.UpsertStandardFoodCache(food)
+		}
+	} else {
+		if food, err := s.foodRepo.FindCustomFoodByID(survivor.ID); err == nil {
+			s.foodService.UpsertCustomFoodCache(food)
+		}
+	}
+
+	return result, nil
+}
+
+// normalized_name unique 색인 도입 전에 생긴 중복 custom 음식들을 MergeFoods로 하나씩 합침
+// 사용자가 가장 많은 음식(같으면 먼저 만들어진 음식)을 남김
+func (s *foodAdminService) MergeDuplicateCustomFoods(dryRun bool) (*models.CustomFoodDedupeReport, error) {
+	if err := s.foodRepo.BackfillCustomFoodNormalizedNames(); err != nil {
+		return nil, err
+	}
+	duplicates, err := s.foodRepo.FindDuplicateCustomFoods()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.CustomFoodDedupeReport{
+		DryRun: dryRun,
+		Groups: make([]models.CustomFoodDedupeGroup, 0, len(duplicates)),
+	}
+	for _, foods := range duplicates {
+		sort.Slice(foods, func(i, j int) bool {
+			if len(foods[i].UsingUserIDs) != len(foods[j].UsingUserIDs) {
+				return len(foods[i].UsingUserIDs) > len(foods[j].UsingUserIDs)
+			}
+			return foods[i].CreatedAt.Before(foods[j].CreatedAt)
+		})
+
+		group := models.CustomFoodDedupeGroup{
+			NormalizedName: foods[0].NormalizedName,
+			Survivor:       models.ValidationOutput{ID: foods[0].ID, Name: foods[0].Name, Type: "custom"},
+			MergedFoods:    make([]models.ValidationOutput, 0, len(foods)-1),
+		}
+		victimIDs := make([]string, 0, len(foods)-1)
+		for _, food := range foods[1:] {
+			group.MergedFoods = append(group.MergedFoods, models.ValidationOutput{ID: food.ID, Name: food.Name, Type: "custom"})
+			victimIDs = append(victimIDs, food.ID.Hex())
+		}
+
+		if !dryRun {
+			result, err := s.MergeFoods(models.MergeFoodsInput{SurvivorID: foods[0].ID.Hex(), VictimIDs: victimIDs})
+			if err != nil {
+				return report, err
+			}
+			group.UpdatedReviews = result.UpdatedReviews
+		}
+		report.Groups = append(report.Groups, group)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,387 @@
+// api/services/food_cache.go
+
+package services
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/seojoonrp/bapddang-server/models"
+	"github.com/seojoonrp/bapddang-server/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 음식 캐시
+// ID, 정규화한 이름(NormalizeBasic), type+speed 색인과 유사도 검색용 n-gram 색인을 넣고 뺄 때마다 함께 관리함
+// 캐시 안의 음식은 캐시만 가지고 있도록 넣을 때와 꺼낼 때 모두 복사함
+// (꺼낸 음식을 핸들러에서 고쳐도 다른 요청이 보는 캐시는 바뀌지 않음)
+
+type foodTypeSpeed struct {
+	foodType string
+	speed    string
+}
+
+type foodCache struct {
+	lock sync.RWMutex
+
+	standardFoods            map[primitive.ObjectID]*models.StandardFood
+	standardFoodsByName      map[string]primitive.ObjectID
+	standardFoodsByTypeSpeed map[foodTypeSpeed]map[primitive.ObjectID]struct{}
+	standardFoodIndex        *utils.NgramIndex[primitive.ObjectID, *models.StandardFood]
+
+	customFoods       map[primitive.ObjectID]*models.CustomFood
+	customFoodsByName map[string]primitive.ObjectID
+	customFoodIndex   *utils.NgramIndex[primitive.ObjectID, *models.CustomFood]
+
+	// 캐시가 바뀔 때마다 올라가는 버전과 동기화 시각
+	version        uint64
+	lastFullSyncAt time.Time
+	lastChangeAt   time.Time
+}
+
+func newFoodCache(standardFoods []*models.StandardFood, customFoods []*models.CustomFood) *foodCache {
+	cache := &foodCache{
+		version:        1,
+		lastFullSyncAt: time.Now(),
+	}
+	cache.resetStandardFoodsLocked(standardFoods)
+	cache.resetCustomFoodsLocked(customFoods)
+	return cache
+}
+
+func (c *foodCache) touchLocked() {
+	c.version++
+	c.lastChangeAt = time.Now()
+}
+
+func copyStandardFood(food *models.StandardFood) *models.StandardFood {
+	copied := *food
+	copied.Categories = slices.Clone(food.Categories)
+	copied.Aliases = slices.Clone(food.Aliases)
+	if food.ImageVariants != nil {
+		variants := *food.ImageVariants
+		copied.ImageVariants = &variants
+	}
+	return &copied
+}
+
+func copyCustomFood(food *models.CustomFood) *models.CustomFood {
+	copied := *food
+	copied.UsingUserIDs = slices.Clone(food.UsingUserIDs)
+	return &copied
+}
+
+// standard 음식 조회
+
+func (c *foodCache) standardFood(id primitive.ObjectID) (*models.StandardFood, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	food, exists := c.standardFoods[id]
+	if !exists {
+		return nil, false
+	}
+	return copyStandardFood(food), true
+}
+
+// ids 순서대로 돌려주고, 캐시에 없는 ID는 건너뜀
+func (c *foodCache) standardFoodsByIDs(ids []primitive.ObjectID) []*models.StandardFood {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	foods := make([]*models.StandardFood, 0, len(ids))
+	for _, id := range ids {
+		if food, exists := c.standardFoods[id]; exists {
+			foods = append(foods, copyStandardFood(food))
+		}
+	}
+	return foods
+}
+
+// 이름이나 별칭이 정규화했을 때 같은 음식
+func (c *foodCache) standardFoodByName(name string) (*models.StandardFood, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	id, exists := c.standardFoodsByName[utils.NormalizeBasic(name)]
+	if !exists {
+		return nil, false
+	}
+	return copyStandardFood(c.standardFoods[id]), true
+}
+
+// 정규화한 이름/별칭 → 음식 ID 색인의 복사본
+func (c *foodCache) standardFoodNameOwners() foodNameOwners {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	owners := make(foodNameOwners, len(c.standardFoodsByName))
+	for name, id := range c.standardFoodsByName {
+		owners[name] = id
+	}
+	return owners
+}
+
+func (c *foodCache) standardFoodsByTypeAndSpeed(foodType, speed string) []*models.StandardFood {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	ids := c.standardFoodsByTypeSpeed[foodTypeSpeed{foodType: foodType, speed: speed}]
+	foods := make([]*models.StandardFood, 0, len(ids))
+	for id := range ids {
+		foods = append(foods, copyStandardFood(c.standardFoods[id]))
+	}
+	return foods
+}
+
+// n-gram 색인으로 추린 후보마다 visit을 호출함
+// 후보 전체를 복사하지 않도록 캐시 안의 음식을 그대로 넘기므로 visit에서는 읽기만 해야 함
+// (캐시는 음식을 제자리에서 고치지 않고 새 복사본으로 교체하므로, 읽기만 한다면 잠금 밖에서 잠깐 들고 있어도 안전함)
+func (c *foodCache) visitStandardCandidates(query string, visit func(food *models.StandardFood)) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	for _, food := range c.standardFoodIndex.Candidates(query) {
+		visit(food)
+	}
+}
+
+// standard 음식 변경
+
+func (c *foodCache) upsertStandardFood(food *models.StandardFood) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.putStandardFoodLocked(copyStandardFood(food))
+	c.touchLocked()
+}
+
+// 캐시 안의 음식을 복사해서 update를 적용한 뒤 교체함. 캐시에 없으면 false
+func (c *foodCache) updateStandardFood(id primitive.ObjectID, update func(food *models.StandardFood)) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	food, exists := c.standardFoods[id]
+	if !exists {
+		return false
+	}
+	updated := copyStandardFood(food)
+	update(updated)
+	c.putStandardFoodLocked(updated)
+	c.touchLocked()
+	return true
+}
+
+func (c *foodCache) removeStandardFood(id primitive.ObjectID) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, exists := c.standardFoods[id]; !exists {
+		return
+	}
+	c.deleteStandardFoodLocked(id)
+	c.touchLocked()
+}
+
+// 새 색인은 잠금 밖에서 만들어 두고 교체만 잠금 안에서 함
+func (c *foodCache) replaceStandardFoods(foods []*models.StandardFood) {
+	fresh := &foodCache{}
+	fresh.resetStandardFoodsLocked(foods)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.standardFoods = fresh.standardFoods
+	c.standardFoodsByName = fresh.standardFoodsByName
+	c.standardFoodsByTypeSpeed = fresh.standardFoodsByTypeSpeed
+	c.standardFoodIndex = fresh.standardFoodIndex
+	c.touchLocked()
+}
+
+func (c *foodCache) resetStandardFoodsLocked(foods []*models.StandardFood) {
+	c.standardFoods = make(map[primitive.ObjectID]*models.StandardFood, len(foods))
+	c.standardFoodsByName = make(map[string]primitive.ObjectID, len(foods))
+	c.standardFoodsByTypeSpeed = make(map[foodTypeSpeed]map[primitive.ObjectID]struct{})
+	c.standardFoodIndex = utils.NewNgramIndex[primitive.ObjectID, *models.StandardFood](utils.DefaultMinGramOverlap)
+	for _, food := range foods {
+		c.putStandardFoodLocked(copyStandardFood(food))
+	}
+}
+
+func (c *foodCache) putStandardFoodLocked(food *models.StandardFood) {
+	c.deleteStandardFoodLocked(food.ID)
+
+	c.standardFoods[food.ID] = food
+	for _, name := range append([]string{food.Name}, food.Aliases...) {
+		c.standardFoodsByName[utils.NormalizeBasic(name)] = food.ID
+	}
+
+	key := foodTypeSpeed{foodType: food.Type, speed: food.Speed}
+	ids, exists := c.standardFoodsByTypeSpeed[key]
+	if !exists {
+		ids = make(map[primitive.ObjectID]struct{})
+		c.standardFoodsByTypeSpeed[key] = ids
+	}
+	ids[food.ID] = struct{}{}
+
+	indexStandardFood(c.standardFoodIndex, food)
+}
+
+func (c *foodCache) deleteStandardFoodLocked(id primitive.ObjectID) {
+	food, exists := c.standardFoods[id]
+	if !exists {
+		return
+	}
+
+	delete(c.standardFoods, id)
+	for _, name := range append([]string{food.Name}, food.Aliases...) {
+		normalized := utils.NormalizeBasic(name)
+		if c.standardFoodsByName[normalized] == id {
+			delete(c.standardFoodsByName, normalized)
+		}
+	}
+
+	key := foodTypeSpeed{foodType: food.Type, speed: food.Speed}
+	delete(c.standardFoodsByTypeSpeed[key], id)
+	if len(c.standardFoodsByTypeSpeed[key]) == 0 {
+		delete(c.standardFoodsByTypeSpeed, key)
+	}
+
+	c.standardFoodIndex.Remove(id)
+}
+
+// custom 음식 조회
+
+func (c *foodCache) customFoodByName(name string) (*models.CustomFood, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	id, exists := c.customFoodsByName[utils.NormalizeBasic(name)]
+	if !exists {
+		return nil, false
+	}
+	return copyCustomFood(c.customFoods[id]), true
+}
+
+// visitStandardCandidates와 같이 visit에서는 읽기만 해야 함
+func (c *foodCache) visitCustomCandidates(query string, visit func(food *models.CustomFood)) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	for _, food := range c.customFoodIndex.Candidates(query) {
+		visit(food)
+	}
+}
+
+// custom 음식 변경
+
+func (c *foodCache) upsertCustomFood(food *models.CustomFood) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.putCustomFoodLocked(copyCustomFood(food))
+	c.touchLocked()
+}
+
+func (c *foodCache) removeCustomFood(id primitive.ObjectID) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, exists := c.customFoods[id]; !exists {
+		return
+	}
+	c.deleteCustomFoodLocked(id)
+	c.touchLocked()
+}
+
+func (c *foodCache) replaceCustomFoods(foods []*models.CustomFood) {
+	fresh := &foodCache{}
+	fresh.resetCustomFoodsLocked(foods)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.customFoods = fresh.customFoods
+	c.customFoodsByName = fresh.customFoodsByName
+	c.customFoodIndex = fresh.customFoodIndex
+	c.touchLocked()
+}
+
+func (c *foodCache) resetCustomFoodsLocked(foods []*models.CustomFood) {
+	c.customFoods = make(map[primitive.ObjectID]*models.CustomFood, len(foods))
+	c.customFoodsByName = make(map[string]primitive.ObjectID, len(foods))
+	c.customFoodIndex = utils.NewNgramIndex[primitive.ObjectID, *models.CustomFood](utils.DefaultMinGramOverlap)
+	for _, food := range foods {
+		c.putCustomFoodLocked(copyCustomFood(food))
+	}
+}
+
+func (c *foodCache) putCustomFoodLocked(food *models.CustomFood) {
+	c.deleteCustomFoodLocked(food.ID)
+
+	c.customFoods[food.ID] = food
+	c.customFoodsByName[utils.NormalizeBasic(food.Name)] = food.ID
+	indexCustomFood(c.customFoodIndex, food)
+}
+
+func (c *foodCache) deleteCustomFoodLocked(id primitive.ObjectID) {
+	food, exists := c.customFoods[id]
+	if !exists {
+		return
+	}
+
+	delete(c.customFoods, id)
+	normalized := utils.NormalizeBasic(food.Name)
+	if c.customFoodsByName[normalized] == id {
+		delete(c.customFoodsByName, normalized)
+	}
+	c.customFoodIndex.Remove(id)
+}
+
+// 상태
+
+func (c *foodCache) markFullSync() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.lastFullSyncAt = time.Now()
+}
+
+func (c *foodCache) status() models.FoodCacheStatus {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	status := models.FoodCacheStatus{
+		Version:           c.version,
+		StandardFoodCount: len(c.standardFoods),
+		CustomFoodCount:   len(c.customFoods),
+		LastFullSyncAt:    c.lastFullSyncAt,
+		AgeSeconds:        time.Since(c.lastFullSyncAt).Seconds(),
+		ChangeStreams:     []models.FoodChangeStreamStatus{},
+	}
+	if !c.lastChangeAt.IsZero() {
+		lastChangeAt := c.lastChangeAt
+		status.LastChangeAt = &lastChangeAt
+	}
+	return status
+}
+
+// 이름/별칭/영문 이름을 함께 색인해 어느 것으로도 후보에 걸리도록 함
+func indexStandardFood(index *utils.NgramIndex[primitive.ObjectID, *models.StandardFood], food *models.StandardFood) {
+	index.Set(food.ID, food, withRomanizedTexts(standardFoodNames(food))...)
+}
+
+func indexCustomFood(index *utils.NgramIndex[primitive.ObjectID, *models.CustomFood], food *models.CustomFood) {
+	index.Set(food.ID, food, withRomanizedTexts([]string{food.Name})...)
+}
+
+// 영문 검색어로도 후보에 걸리도록 로마자 표기와 로마자 비교용 키를 함께 색인함
+func withRomanizedTexts(names []string) []string {
+	texts := make([]string, 0, len(names)*3)
+	texts = append(texts, names...)
+	for _, name := range names {
+		texts = append(texts, utils.Romanize(name), utils.RomanizedKey(name))
+	}
+	return texts
+}
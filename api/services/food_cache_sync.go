@@ -0,0 +1,238 @@
+// api/services/food_cache_sync.go
+
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/seojoonrp/bapddang-server/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// 여러 서버가 떠 있거나 Mongo shell에서 직접 수정해도 음식 캐시가 DB를 따라가도록 함
+// standard_foods/custom_foods의 change stream을 받아 캐시에 반영하고,
+// 스트림이 끊겼다가 이어지지 못하는 경우를 대비해 주기적으로 전체를 다시 읽어옴
+
+const (
+	standardFoodStream = "standard_foods"
+	customFoodStream   = "custom_foods"
+
+	foodCacheResyncInterval = 10 * time.Minute
+	changeStreamRetryMin    = 5 * time.Second
+	changeStreamRetryMax    = time.Minute
+)
+
+// resume token 이후의 oplog가 이미 지워져서 이어받을 수 없을 때의 서버 에러 코드
+const (
+	changeStreamFatalErrorCode  = 280
+	changeStreamHistoryLostCode = 286
+)
+
+type foodChangeEvent[T any] struct {
+	OperationType string `bson:"operationType"`
+	DocumentKey   struct {
+		ID primitive.ObjectID `bson:"_id"`
+	} `bson:"documentKey"`
+	FullDocument *T `bson:"fullDocument"`
+}
+
+type openFoodChangeStream func(ctx context.Context, resumeToken bson.Raw) (*mongo.ChangeStream, error)
+
+func (s *foodService) StartCacheSync() {
+	go watchFoodChanges(s, standardFoodStream, s.foodRepo.WatchStandardFoods, s.ReloadStandardFoodCache, &s.standardReloadLock,
+		s.UpsertStandardFoodCache, s.RemoveStandardFoodCache)
+	go watchFoodChanges(s, customFoodStream, s.foodRepo.WatchCustomFoods, s.reloadCustomFoodCache, &s.customReloadLock,
+		s.UpsertCustomFoodCache, s.RemoveCustomFoodCache)
+
+	go func() {
+		ticker := time.NewTicker(foodCacheResyncInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := s.resyncFoodCaches(); err != nil {
+				log.Printf("Failed to resync food cache: %v", err)
+			}
+		}
+	}()
+}
+
+// 스트림이 끊기면 마지막 resume token부터 다시 열고, 토큰을 쓸 수 없으면 토큰 없이 연 뒤 해당 컬렉션을 전체 재동기화함
+// reloadLock은 reload가 잡는 락으로, 이벤트 하나를 반영할 때마다 잡아서 전체 다시 읽기와 겹치지 않게 함
+func watchFoodChanges[T any](s *foodService, name string, open openFoodChangeStream, reload func() error, reloadLock *sync.Mutex, upsert func(*T), remove func(primitive.ObjectID)) {
+	ctx := context.Background()
+	var resumeToken bson.Raw
+	retryDelay := changeStreamRetryMin
+
+	for {
+		stream, err := open(ctx, resumeToken)
+		if err == nil {
+			s.updateChangeStream(name, func(status *models.FoodChangeStreamStatus) {
+				status.Active = true
+				status.LastError = ""
+			})
+			retryDelay = changeStreamRetryMin
+
+			// 토큰 없이 열었으면 그 전까지의 변경은 받지 못했으므로 한 번 전체를 다시 읽음
+			if resumeToken == nil {
+				if err := reload(); err != nil {
+					log.Printf("Failed to reload %s cache: %v", name, err)
+				}
+			}
+
+			resumeToken, err = consumeFoodChanges(ctx, s, name, stream, resumeToken, reloadLock, upsert, remove)
+			stream.Close(ctx)
+		}
+
+		if isChangeStreamHistoryLost(err) {
+			resumeToken = nil
+		}
+		log.Printf("Food change stream %s stopped: %v", name, err)
+		s.updateChangeStream(name, func(status *models.FoodChangeStreamStatus) {
+			status.Active = false
+			status.Resumable = resumeToken != nil
+			status.Restarts++
+			if err != nil {
+				status.LastError = err.Error()
+			}
+		})
+
+		time.Sleep(retryDelay)
+		retryDelay = min(retryDelay*2, changeStreamRetryMax)
+	}
+}
+
+// 스트림이 끝날 때까지 이벤트를 캐시에 반영하고, 이어받을 수 있는 마지막 resume token을 돌려줌
+func consumeFoodChanges[T any](ctx context.Context, s *foodService, name string, stream *mongo.ChangeStream, resumeToken bson.Raw, reloadLock *sync.Mutex, upsert func(*T), remove func(primitive.ObjectID)) (bson.Raw, error) {
+	for stream.Next(ctx) {
+		var event foodChangeEvent[T]
+		if err := stream.Decode(&event); err != nil {
+			log.Printf("Failed to decode %s change event: %v", name, err)
+			resumeToken = stream.ResumeToken()
+			continue
+		}
+
+		if event.OperationType == "invalidate" {
+			// 컬렉션이 drop/rename되면 이 스트림은 이어받을 수 없음
+			return nil, errors.New("change stream invalidated")
+		}
+
+		reloadLock.Lock()
+		switch event.OperationType {
+		case "insert", "update", "replace":
+			// update 직후 삭제되면 fullDocument를 찾지 못해 비어 있음
+			if event.FullDocument == nil {
+				remove(event.DocumentKey.ID)
+			} else {
+				upsert(event.FullDocument)
+			}
+		case "delete":
+			remove(event.DocumentKey.ID)
+		}
+		reloadLock.Unlock()
+
+		resumeToken = stream.ResumeToken()
+		s.updateChangeStream(name, func(status *models.FoodChangeStreamStatus) {
+			now := time.Now()
+			status.EventCount++
+			status.LastEventAt = &now
+			status.Resumable = true
+		})
+	}
+
+	if err := stream.Err(); err != nil {
+		return resumeToken, err
+	}
+	return resumeToken, errors.New("change stream closed")
+}
+
+func isChangeStreamHistoryLost(err error) bool {
+	var commandErr mongo.CommandError
+	if errors.As(err, &commandErr) {
+		return commandErr.Code == changeStreamHistoryLostCode || commandErr.Code == changeStreamFatalErrorCode
+	}
+	return false
+}
+
+func (s *foodService) updateChangeStream(name string, update func(status *models.FoodChangeStreamStatus)) {
+	s.syncLock.Lock()
+	defer s.syncLock.Unlock()
+
+	status, exists := s.changeStreams[name]
+	if !exists {
+		status = &models.FoodChangeStreamStatus{Collection: name}
+		s.changeStreams[name] = status
+	}
+	update(status)
+}
+
+func (s *foodService) changeStreamActive(name string) bool {
+	s.syncLock.Lock()
+	defer s.syncLock.Unlock()
+
+	status, exists := s.changeStreams[name]
+	return exists && status.Active
+}
+
+func (s *foodService) reloadCustomFoodCache() error {
+	s.customReloadLock.Lock()
+	defer s.customReloadLock.Unlock()
+
+	allCustomFoods, err := s.foodRepo.GetAllCustomFoods()
+	if err != nil {
+		return err
+	}
+
+	s.cache.replaceCustomFoods(allCustomFoods)
+
+	log.Printf("Reloaded %d custom foods into cache", len(allCustomFoods))
+	return nil
+}
+
+// 각 컬렉션을 다시 읽는 동안에는 change stream 이벤트 반영이 멈췄다가 캐시를 교체한 뒤에 이어지므로,
+// 읽는 사이에 바뀐 음식도 교체된 캐시 위에 다시 반영됨
+// (읽기 전에 일어난 변경이 뒤늦게 반영되어 잠시 예전 값이 되더라도, 그 뒤의 변경 이벤트가 같은 스트림으로 들어와 바로잡힘)
+func (s *foodService) resyncFoodCaches() error {
+	if err := s.ReloadStandardFoodCache(); err != nil {
+		return err
+	}
+	if err := s.reloadCustomFoodCache(); err != nil {
+		return err
+	}
+
+	s.cache.markFullSync()
+	return nil
+}
+
+func (s *foodService) GetCacheStatus() models.FoodCacheStatus {
+	status := s.cache.status()
+
+	hasIndex, err := s.foodRepo.HasCustomFoodNameIndex()
+	status.CustomFoodNameIndex = hasIndex
+	if err != nil {
+		status.CustomFoodNameIndexError = err.Error()
+	}
+
+	s.syncLock.Lock()
+	defer s.syncLock.Unlock()
+
+	for _, name := range []string{standardFoodStream, customFoodStream} {
+		stream, exists := s.changeStreams[name]
+		if !exists {
+			continue
+		}
+		streamStatus := *stream
+		if stream.LastEventAt != nil {
+			lastEventAt := *stream.LastEventAt
+			streamStatus.LastEventAt = &lastEventAt
+		}
+		status.ChangeStreams = append(status.ChangeStreams, streamStatus)
+	}
+
+	return status
+}
@@ -0,0 +1,414 @@
+// api/services/food_catalog_service.go
+
+// standard 음식 카탈로그 일괄 가져오기/내보내기
+// CSV와 JSON 모두 NewStandardFoodInput 한 건이 한 행이고, 내보낸 파일을 그대로 다시 가져올 수 있음
+
+package services
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/seojoonrp/bapddang-server/api/repositories"
+	"github.com/seojoonrp/bapddang-server/models"
+	"github.com/seojoonrp/bapddang-server/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	FoodCatalogFormatCSV  = "csv"
+	FoodCatalogFormatJSON = "json"
+
+	foodImportBatchSize = 500
+	listSeparator       = "|"
+)
+
+var foodCatalogCSVHeader = []string{"name", "imageURL", "speed", "type", "categories", "aliases", "englishName"}
+
+type FoodCatalogService interface {
+	ImportStandardFoods(catalog *models.FoodCatalog, dryRun bool) (*models.FoodImportReport, error)
+	ExportStandardFoods() ([]models.NewStandardFoodInput, error)
+}
+
+type foodCatalogService struct {
+	foodRepo    repositories.FoodRepository
+	foodService FoodService
+}
+
+// foodService가 nil이면 캐시 갱신을 건너뜀 (캐시를 들고 있지 않은 CLI에서 사용)
+func NewFoodCatalogService(foodRepo repositories.FoodRepository, foodService FoodService) FoodCatalogService {
+	return &foodCatalogService{
+		foodRepo:    foodRepo,
+		foodService: foodService,
+	}
+}
+
+// 이름을 NormalizeBasic으로 정규화해 기존 음식과 대조함
+// 새 음식은 생성, 내용이 다른 기존 음식은 수정, 같은 음식은 건너뜀
+// 잘못된 행이 하나라도 있으면 아무것도 반영하지 않고 보고서만 돌려줌
+func (s *foodCatalogService) ImportStandardFoods(catalog *models.FoodCatalog, dryRun bool) (*models.FoodImportReport, error) {
+	rows := catalog.Rows
+	existingFoods, err := s.foodRepo.GetAllStandardFoods()
+	if err != nil {
+		return nil, err
+	}
+
+	existingByName := make(map[string]*models.StandardFood, len(existingFoods))
+	for _, food := range existingFoods {
+		existingByName[utils.NormalizeBasic(food.Name)] = food
+	}
+
+	report := &models.FoodImportReport{
+		TotalRows: len(rows),
+		Created:   []models.FoodImportChange{},
+		Updated:   []models.FoodImportChange{},
+		Errors:    []models.FoodImportRowError{},
+		DryRun:    dryRun,
+	}
+
+	seenRows := make(map[string]int)
+	upserts := make([]*models.StandardFood, 0, len(rows))
+	upsertRows := make([]int, 0, len(rows))
+
+	for i, catalogRow := range rows {
+		rowNumber := i + 1
+		row := normalizeFoodImportRow(catalogRow.NewStandardFoodInput)
+
+		normalizedName := utils.NormalizeBasic(row.Name)
+		existing, exists := existingByName[normalizedName]
+		if exists {
+			row = fillMissingFoodImportColumns(row, catalogRow.Columns, existing)
+		}
+
+		if err := validateFoodAttributes(row.Name, row.Speed, row.Type); err != nil {
+			report.Errors = append(report.Errors, models.FoodImportRowError{Row: rowNumber, Name: row.Name, Error: err.Error()})
+			continue
+		}
+		if err := validateEnglishName(row.EnglishName); err != nil {
+			report.Errors = append(report.Errors, models.FoodImportRowError{Row: rowNumber, Name: row.Name, Error: err.Error()})
+			continue
+		}
+
+		if firstRow, exists := seenRows[normalizedName]; exists {
+			report.Errors = append(report.Errors, models.FoodImportRowError{
+				Row:   rowNumber,
+				Name:  row.Name,
+				Error: "duplicate of row " + strconv.Itoa(firstRow),
+			})
+			continue
+		}
+		seenRows[normalizedName] = rowNumber
+
+		if !exists {
+			newFood := &models.StandardFood{
+				ID:          primitive.NewObjectID(),
+				Name:        row.Name,
+				ImageURL:    row.ImageURL,
+				Speed:       row.Speed,
+				Type:        row.Type,
+				Categories:  row.Categories,
+				Aliases:     row.Aliases,
+				EnglishName: row.EnglishName,
+			}
+			upserts = append(upserts, newFood)
+			upsertRows = append(upsertRows, rowNumber)
+			report.Created = append(report.Created, models.FoodImportChange{Row: rowNumber, FoodID: newFood.ID, After: row})
+			continue
+		}
+
+		before := standardFoodToInput(existing)
+		if foodImportRowEqual(before, row) {
+			report.UnchangedCount++
+			continue
+		}
+
+		updated := *existing
+		updated.Name = row.Name
+		updated.ImageURL = row.ImageURL
+		updated.Speed = row.Speed
+		updated.Type = row.Type
+		updated.Categories = row.Categories
+		updated.Aliases = row.Aliases
+		updated.EnglishName = row.EnglishName
+		upserts = append(upserts, &updated)
+		upsertRows = append(upsertRows, rowNumber)
+		report.Updated = append(report.Updated, models.FoodImportChange{Row: rowNumber, FoodID: existing.ID, Before: &before, After: row})
+	}
+
+	if len(report.Errors) == 0 {
+		report.Errors = checkImportNameConflicts(existingFoods, upserts, upsertRows)
+	}
+
+	if dryRun || len(report.Errors) > 0 || len(upserts) == 0 {
+		return report, nil
+	}
+
+	for start := 0; start < len(upserts); start += foodImportBatchSize {
+		end := min(start+foodImportBatchSize, len(upserts))
+		if err := s.foodRepo.UpsertStandardFoods(upserts[start:end]); err != nil {
+			return nil, err
+		}
+	}
+	report.Applied = true
+
+	if s.foodService != nil {
+		if err := s.foodService.ReloadStandardFoodCache(); err != nil {
+			log.Printf("Failed to reload standard food cache after import: %v", err)
+		}
+	}
+
+	return report, nil
+}
+
+// 가져오기가 끝난 뒤의 상태를 기준으로 이름/별칭이 서로 다른 음식끼리 겹치는지 검사
+// 이번에 바뀌지 않는 기존 음식이 먼저 이름을 차지하고, 그다음 행 순서대로 차지함
+// 같은 파일 안의 두 행이 같은 별칭을 쓰는 경우도 뒤쪽 행의 에러로 보고함
+func checkImportNameConflicts(existingFoods []*models.StandardFood, upserts []*models.StandardFood, upsertRows []int) []models.FoodImportRowError {
+	touched := make(map[primitive.ObjectID]bool, len(upserts))
+	for _, food := range upserts {
+		touched[food.ID] = true
+	}
+
+	owners := make(foodNameOwners)
+	for _, food := range existingFoods {
+		if !touched[food.ID] {
+			owners.claim(food.ID, food.Name, food.Aliases)
+		}
+	}
+
+	rowErrors := []models.FoodImportRowError{}
+	for i, food := range upserts {
+		if owners.takenByOther(food.ID, food.Name) {
+			rowErrors = append(rowErrors, models.FoodImportRowError{Row: upsertRows[i], Name: food.Name, Error: ErrFoodAlreadyExists.Error()})
+			continue
+		}
+
+		aliases, err := cleanAliases(food.ID, food.Name, food.Aliases, owners)
+		if err != nil {
+			rowErrors = append(rowErrors, models.FoodImportRowError{Row: upsertRows[i], Name: food.Name, Error: err.Error()})
+			continue
+		}
+		food.Aliases = aliases
+		owners.claim(food.ID, food.Name, food.Aliases)
+	}
+
+	return rowErrors
+}
+
+func (s *foodCatalogService) ExportStandardFoods() ([]models.NewStandardFoodInput, error) {
+	foods, err := s.foodRepo.GetAllStandardFoods()
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]models.NewStandardFoodInput, 0, len(foods))
+	for _, food := range foods {
+		rows = append(rows, standardFoodToInput(food))
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].Name < rows[j].Name
+	})
+
+	return rows, nil
+}
+
+// ParseFoodCatalog: CSV는 첫 행을 헤더로 사용하며 열 순서는 자유, 카테고리와 별칭은 "|"로 구분
+// 행마다 파일에 있던 열을 기록해 두어, 일부 열이 없는 파일(예전 형식으로 내보낸 파일 등)이 기존 값을 지우지 않게 함
+func ParseFoodCatalog(format string, r io.Reader) (*models.FoodCatalog, error) {
+	switch format {
+	case FoodCatalogFormatJSON:
+		var records []json.RawMessage
+		if err := json.NewDecoder(r).Decode(&records); err != nil {
+			return nil, errors.New("invalid catalog file")
+		}
+
+		rows := make([]models.FoodCatalogRow, 0, len(records))
+		for _, record := range records {
+			var row models.FoodCatalogRow
+			var fields map[string]json.RawMessage
+			if err := json.Unmarshal(record, &row.NewStandardFoodInput); err != nil {
+				return nil, errors.New("invalid catalog file")
+			}
+			if err := json.Unmarshal(record, &fields); err != nil {
+				return nil, errors.New("invalid catalog file")
+			}
+
+			// encoding/json은 키를 대소문자 구분 없이 매칭하므로 소문자로 맞춰 기록
+			row.Columns = make(map[string]bool, len(fields))
+			for key := range fields {
+				row.Columns[strings.ToLower(key)] = true
+			}
+			rows = append(rows, row)
+		}
+		return &models.FoodCatalog{Rows: rows}, nil
+
+	case FoodCatalogFormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		records, err := reader.ReadAll()
+		if err != nil || len(records) == 0 {
+			return nil, errors.New("invalid catalog file")
+		}
+
+		columns := make(map[string]int)
+		presentColumns := make(map[string]bool)
+		for i, column := range records[0] {
+			column = strings.ToLower(strings.TrimSpace(column))
+			columns[column] = i
+			presentColumns[column] = true
+		}
+		if _, exists := columns["name"]; !exists {
+			return nil, errors.New("invalid catalog file")
+		}
+
+		field := func(record []string, column string) string {
+			i, exists := columns[strings.ToLower(column)]
+			if !exists || i >= len(record) {
+				return ""
+			}
+			return record[i]
+		}
+
+		rows := make([]models.FoodCatalogRow, 0, len(records)-1)
+		for _, record := range records[1:] {
+			rows = append(rows, models.FoodCatalogRow{
+				NewStandardFoodInput: models.NewStandardFoodInput{
+					Name:        field(record, "name"),
+					ImageURL:    field(record, "imageURL"),
+					Speed:       field(record, "speed"),
+					Type:        field(record, "type"),
+					Categories:  splitList(field(record, "categories")),
+					Aliases:     splitList(field(record, "aliases")),
+					EnglishName: field(record, "englishName"),
+				},
+				Columns: presentColumns,
+			})
+		}
+		return &models.FoodCatalog{Rows: rows}, nil
+	}
+
+	return nil, errors.New("unsupported catalog format")
+}
+
+func WriteFoodCatalog(format string, w io.Writer, rows []models.NewStandardFoodInput) error {
+	switch format {
+	case FoodCatalogFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(rows)
+
+	case FoodCatalogFormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(foodCatalogCSVHeader); err != nil {
+			return err
+		}
+		for _, row := range rows {
+			record := []string{
+				row.Name,
+				row.ImageURL,
+				row.Speed,
+				row.Type,
+				strings.Join(row.Categories, listSeparator),
+				strings.Join(row.Aliases, listSeparator),
+				row.EnglishName,
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+
+	return errors.New("unsupported catalog format")
+}
+
+func normalizeFoodImportRow(row models.NewStandardFoodInput) models.NewStandardFoodInput {
+	row.Name = strings.TrimSpace(row.Name)
+	row.ImageURL = strings.TrimSpace(row.ImageURL)
+	row.Speed = strings.TrimSpace(row.Speed)
+	row.Type = strings.TrimSpace(row.Type)
+	row.EnglishName = strings.TrimSpace(row.EnglishName)
+
+	row.Categories = trimList(row.Categories)
+	row.Aliases = trimList(row.Aliases)
+
+	return row
+}
+
+// 파일에 없던 열은 기존 음식의 값으로 채움
+func fillMissingFoodImportColumns(row models.NewStandardFoodInput, columns map[string]bool, existing *models.StandardFood) models.NewStandardFoodInput {
+	before := standardFoodToInput(existing)
+
+	if !columns["imageurl"] {
+		row.ImageURL = before.ImageURL
+	}
+	if !columns["speed"] {
+		row.Speed = before.Speed
+	}
+	if !columns["type"] {
+		row.Type = before.Type
+	}
+	if !columns["categories"] {
+		row.Categories = before.Categories
+	}
+	if !columns["aliases"] {
+		row.Aliases = before.Aliases
+	}
+	if !columns["englishname"] {
+		row.EnglishName = before.EnglishName
+	}
+
+	return row
+}
+
+func trimList(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func splitList(value string) []string {
+	return trimList(strings.Split(value, listSeparator))
+}
+
+func standardFoodToInput(food *models.StandardFood) models.NewStandardFoodInput {
+	return models.NewStandardFoodInput{
+		Name:        food.Name,
+		ImageURL:    food.ImageURL,
+		Speed:       food.Speed,
+		Type:        food.Type,
+		Categories:  trimList(food.Categories),
+		Aliases:     trimList(food.Aliases),
+		EnglishName: food.EnglishName,
+	}
+}
+
+func foodImportRowEqual(a, b models.NewStandardFoodInput) bool {
+	if a.Name != b.Name || a.ImageURL != b.ImageURL || a.Speed != b.Speed || a.Type != b.Type || a.EnglishName != b.EnglishName {
+		return false
+	}
+	return stringSlicesEqual(a.Categories, b.Categories) && stringSlicesEqual(a.Aliases, b.Aliases)
+}
+
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
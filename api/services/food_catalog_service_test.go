@@ -0,0 +1,160 @@
+// api/services/food_catalog_service_test.go
+
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/seojoonrp/bapddang-server/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCheckImportNameConflicts(t *testing.T) {
+	gukbap := &models.StandardFood{ID: primitive.NewObjectID(), Name: "돼지국밥", Aliases: []string{"국밥"}}
+	ramen := &models.StandardFood{ID: primitive.NewObjectID(), Name: "라면", Aliases: []string{"라멘"}}
+	existing := []*models.StandardFood{gukbap, ramen}
+
+	food := func(id primitive.ObjectID, name string, aliases ...string) *models.StandardFood {
+		return &models.StandardFood{ID: id, Name: name, Aliases: aliases}
+	}
+
+	tests := []struct {
+		name      string
+		upserts   []*models.StandardFood
+		wantError map[int]string
+	}{
+		{
+			name:    "겹치지 않는 새 음식",
+			upserts: []*models.StandardFood{food(primitive.NewObjectID(), "김치찌개", "김찌")},
+		},
+		{
+			name:      "바뀌지 않는 기존 음식의 별칭과 겹침",
+			upserts:   []*models.StandardFood{food(primitive.NewObjectID(), "순대국밥", "국밥")},
+			wantError: map[int]string{1: ErrAliasInUse.Error()},
+		},
+		{
+			name:      "새 음식 이름이 기존 별칭과 겹침",
+			upserts:   []*models.StandardFood{food(primitive.NewObjectID(), "라멘")},
+			wantError: map[int]string{1: ErrFoodAlreadyExists.Error()},
+		},
+		{
+			name: "같은 파일 안에서 별칭이 겹치면 뒤쪽 행만 에러",
+			upserts: []*models.StandardFood{
+				food(primitive.NewObjectID(), "김치찌개", "찌개"),
+				food(primitive.NewObjectID(), "된장찌개", "찌개"),
+			},
+			wantError: map[int]string{2: ErrAliasInUse.Error()},
+		},
+		{
+			name: "같은 파일에서 기존 음식이 놓아준 별칭은 다른 음식이 쓸 수 있음",
+			upserts: []*models.StandardFood{
+				food(gukbap.ID, "돼지국밥"),
+				food(primitive.NewObjectID(), "순대국밥", "국밥"),
+			},
+		},
+		{
+			name:    "자기 이름과 같은 별칭은 에러 없이 제거",
+			upserts: []*models.StandardFood{food(ramen.ID, "라면", "라면", "라멘")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := make([]int, len(tt.upserts))
+			for i := range rows {
+				rows[i] = i + 1
+			}
+
+			rowErrors := checkImportNameConflicts(existing, tt.upserts, rows)
+			if len(rowErrors) != len(tt.wantError) {
+				t.Fatalf("errors = %+v, want %v", rowErrors, tt.wantError)
+			}
+			for _, rowError := range rowErrors {
+				if want := tt.wantError[rowError.Row]; rowError.Error != want {
+					t.Errorf("row %d error = %q, want %q", rowError.Row, rowError.Error, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseFoodCatalogColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		body    string
+		present []string
+		missing []string
+	}{
+		{
+			name:    "모든 열이 있는 CSV",
+			format:  FoodCatalogFormatCSV,
+			body:    "name,imageURL,speed,type,categories,aliases,englishName\n라면,,fast,meal,면,라멘,ramyeon\n",
+			present: []string{"name", "imageurl", "speed", "type", "categories", "aliases", "englishname"},
+		},
+		{
+			name:    "aliases/englishName 열이 없는 예전 CSV",
+			format:  FoodCatalogFormatCSV,
+			body:    "Name,Speed,Type,Categories\n라면,fast,meal,면\n",
+			present: []string{"name", "speed", "type", "categories"},
+			missing: []string{"aliases", "englishname", "imageurl"},
+		},
+		{
+			name:    "aliases 키가 없는 JSON",
+			format:  FoodCatalogFormatJSON,
+			body:    `[{"name":"라면","speed":"fast","type":"meal","englishName":"ramyeon"}]`,
+			present: []string{"name", "speed", "type", "englishname"},
+			missing: []string{"aliases", "categories", "imageurl"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			catalog, err := ParseFoodCatalog(tt.format, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatalf("ParseFoodCatalog: %v", err)
+			}
+			if len(catalog.Rows) != 1 || catalog.Rows[0].Name != "라면" {
+				t.Fatalf("Rows = %+v, want one row named 라면", catalog.Rows)
+			}
+
+			columns := catalog.Rows[0].Columns
+			for _, column := range tt.present {
+				if !columns[column] {
+					t.Errorf("column %q missing, want present", column)
+				}
+			}
+			for _, column := range tt.missing {
+				if columns[column] {
+					t.Errorf("column %q present, want missing", column)
+				}
+			}
+		})
+	}
+}
+
+func TestFillMissingFoodImportColumns(t *testing.T) {
+	existing := &models.StandardFood{
+		Name:        "라면",
+		ImageURL:    "https://example.com/ramyeon.jpg",
+		Speed:       "fast",
+		Type:        "meal",
+		Categories:  []string{"면"},
+		Aliases:     []string{"라멘"},
+		EnglishName: "ramyeon",
+	}
+	row := models.NewStandardFoodInput{Name: "라면", Speed: "slow", Type: "meal"}
+
+	got := fillMissingFoodImportColumns(row, map[string]bool{"name": true, "speed": true, "type": true}, existing)
+
+	if got.Speed != "slow" {
+		t.Errorf("Speed = %q, want the file value %q", got.Speed, "slow")
+	}
+	if got.ImageURL != existing.ImageURL || got.EnglishName != existing.EnglishName {
+		t.Errorf("ImageURL/EnglishName = %q/%q, want existing values", got.ImageURL, got.EnglishName)
+	}
+	if !stringSlicesEqual(got.Aliases, existing.Aliases) || !stringSlicesEqual(got.Categories, existing.Categories) {
+		t.Errorf("Aliases/Categories = %v/%v, want existing values", got.Aliases, got.Categories)
+	}
+}
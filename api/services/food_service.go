@@ -3,12 +3,12 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"log"
-	"math/rand"
 	"sort"
+	"strings"
 	"sync"
-	"time"
 
 	"github.com/seojoonrp/bapddang-server/api/repositories"
 	"github.com/seojoonrp/bapddang-server/models"
@@ -21,25 +21,42 @@ type FoodService interface {
 	GetStandardFoodByID(id string) (*models.StandardFood, error)
 	GetStandardFoodsByIDs(ids []primitive.ObjectID) ([]*models.StandardFood, error)
 	CreateStandardFood(input models.NewStandardFoodInput) (*models.StandardFood, error)
+	UpdateStandardFoodImage(foodID primitive.ObjectID, image *models.StoredImage) error
 	FindOrCreateCustomFood(input models.NewCustomFoodInput, user models.User) (*models.CustomFood, error)
 
-	GetMainFeedFoods(foodType, speed string, foodCount int) ([]*models.StandardFood, error)
+	GetMainFeedCandidates(foodType, speed string) []*models.StandardFood
 	ValidateFoods(names []string, userID primitive.ObjectID) ([]models.ValidationResult, error)
+	SearchFoods(query models.FoodSearchQuery) (*models.FoodSearchPage, error)
 
-	UpdateCreatedReviewStats(foodIDs []primitive.ObjectID, rating int) error
-	UpdateModifiedReviewStats(foodIDs []primitive.ObjectID, oldRating, newRating int) error
-	SyncRatingStatsCache(foodID primitive.ObjectID, oldRating, newRating int) error
-	UpdateLikeStats(foodID primitive.ObjectID, increment int) error
+	ReloadStandardFoodCache() error
+	UpsertStandardFoodCache(food *models.StandardFood)
+	RemoveStandardFoodCache(foodID primitive.ObjectID)
+	UpsertCustomFoodCache(food *models.CustomFood)
+	RemoveCustomFoodCache(foodID primitive.ObjectID)
+	SyncReviewStatsCache(diffs []models.ReviewStatsDiff)
+	SyncLikeStatsCache(foodID primitive.ObjectID, increment int)
+
+	StartCacheSync()
+	GetCacheStatus() models.FoodCacheStatus
+
+	standardFoodNameOwners() foodNameOwners
 }
 
 type foodService struct {
-	foodRepo          repositories.FoodRepository
-	standardFoodCache []*models.StandardFood
-	customFoodCache   []*models.CustomFood
-	cacheLock         sync.RWMutex
+	foodRepo         repositories.FoodRepository
+	foodRedirectRepo repositories.FoodRedirectRepository
+	cache            *foodCache
+
+	// change stream 상태 (syncLock으로 보호)
+	changeStreams map[string]*models.FoodChangeStreamStatus
+	syncLock      sync.Mutex
+
+	// 전체 다시 읽기(DB 조회 ~ 캐시 교체) 동안 해당 컬렉션의 change stream 이벤트 반영을 멈춤
+	standardReloadLock sync.Mutex
+	customReloadLock   sync.Mutex
 }
 
-func NewFoodService(foodRepo repositories.FoodRepository) FoodService {
+func NewFoodService(foodRepo repositories.FoodRepository, foodRedirectRepo repositories.FoodRedirectRepository) FoodService {
 	allStandardFoods, err := foodRepo.GetAllStandardFoods()
 	if err != nil {
 		log.Fatal("FATAL: Failed to load standard food cache: ", err)
@@ -53,10 +70,10 @@ func NewFoodService(foodRepo repositories.FoodRepository) FoodService {
 	log.Printf("Successfully loaded %d custom foods into cache", len(allCustomFoods))
 
 	return &foodService{
-		foodRepo:          foodRepo,
-		standardFoodCache: allStandardFoods,
-		customFoodCache:   allCustomFoods,
-		cacheLock:         sync.RWMutex{},
+		foodRepo:         foodRepo,
+		foodRedirectRepo: foodRedirectRepo,
+		cache:            newFoodCache(allStandardFoods, allCustomFoods),
+		changeStreams:    make(map[string]*models.FoodChangeStreamStatus),
 	}
 }
 
@@ -67,6 +84,14 @@ func (s *foodService) GetStandardFoodByID(id string) (*models.StandardFood, erro
 	}
 
 	food, err := s.foodRepo.FindStandardFoodByID(foodID)
+	if err == mongo.ErrNoDocuments {
+		// 병합으로 사라진 음식이면 살아남은 음식을 돌려줌
+		redirect, redirectErr := s.foodRedirectRepo.FindByFromID(foodID)
+		if redirectErr != nil || redirect.ToType != "standard" {
+			return nil, err
+		}
+		food, err = s.foodRepo.FindStandardFoodByID(redirect.ToID)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -75,146 +100,99 @@ func (s *foodService) GetStandardFoodByID(id string) (*models.StandardFood, erro
 }
 
 func (s *foodService) GetStandardFoodsByIDs(ids []primitive.ObjectID) ([]*models.StandardFood, error) {
-	s.cacheLock.RLock()
-	defer s.cacheLock.RUnlock()
-
-	results := make([]*models.StandardFood, 0, len(ids))
+	return s.cache.standardFoodsByIDs(ids), nil
+}
 
-	foodMap := make(map[primitive.ObjectID]*models.StandardFood)
-	for _, food := range s.standardFoodCache {
-		foodMap[food.ID] = food
+func (s *foodService) CreateStandardFood(input models.NewStandardFoodInput) (*models.StandardFood, error) {
+	input.EnglishName = strings.TrimSpace(input.EnglishName)
+	if err := validateEnglishName(input.EnglishName); err != nil {
+		return nil, err
 	}
 
-	for _, id := range ids {
-		if food, exists := foodMap[id]; exists {
-			results = append(results, food)
-		}
+	input.Name = strings.TrimSpace(input.Name)
+	owners := s.cache.standardFoodNameOwners()
+	if _, taken := owners[utils.NormalizeBasic(input.Name)]; taken {
+		return nil, ErrFoodAlreadyExists
 	}
 
-	return results, nil
-}
-
-func (s *foodService) CreateStandardFood(input models.NewStandardFoodInput) (*models.StandardFood, error) {
-	_, err := s.foodRepo.FindStandardFoodByName(input.Name)
-	if err == nil {
-		return nil, errors.New("food already exists")
+	foodID := primitive.NewObjectID()
+	aliases, err := cleanAliases(foodID, input.Name, input.Aliases, owners)
+	if err != nil {
+		return nil, err
 	}
 
 	newFood := &models.StandardFood{
-		ID:          primitive.NewObjectID(),
+		ID:          foodID,
 		Name:        input.Name,
 		ImageURL:    input.ImageURL,
 		Speed:       input.Speed,
 		Type:        input.Type,
 		Categories:  input.Categories,
+		Aliases:     aliases,
+		EnglishName: input.EnglishName,
 		LikeCount:   0,
 		ReviewCount: 0,
 		TotalRating: 0,
 	}
-	err = s.foodRepo.SaveStandardFood(newFood)
+	err = s.foodRepo.SaveStandardFood(context.TODO(), newFood)
 	if err != nil {
 		return nil, err
 	}
 
-	s.cacheLock.Lock()
-	s.standardFoodCache = append(s.standardFoodCache, newFood)
-	s.cacheLock.Unlock()
+	s.cache.upsertStandardFood(newFood)
 
 	return newFood, nil
 }
 
-func (s *foodService) FindOrCreateCustomFood(input models.NewCustomFoodInput, user models.User) (*models.CustomFood, error) {
-	existingFood, err := s.foodRepo.FindCustomFoodByName(input.Name)
-
-	if err == mongo.ErrNoDocuments {
-		newFood := &models.CustomFood{
-			ID:           primitive.NewObjectID(),
-			Name:         input.Name,
-			UsingUserIDs: []primitive.ObjectID{user.ID},
-			CreatedAt:    time.Now(),
-		}
-		err := s.foodRepo.SaveCustomFood(newFood)
-		if err != nil {
-			return nil, err
-		}
+func (s *foodService) UpdateStandardFoodImage(foodID primitive.ObjectID, image *models.StoredImage) error {
+	err := s.foodRepo.UpdateStandardFoodImage(foodID, image)
+	if err != nil {
+		return err
+	}
 
-		s.cacheLock.Lock()
-		s.customFoodCache = append(s.customFoodCache, newFood)
-		s.cacheLock.Unlock()
+	s.cache.updateStandardFood(foodID, func(food *models.StandardFood) {
+		variants := image.Variants
+		food.ImageURL = image.URL
+		food.ImageKey = image.Key
+		food.ImageVariants = &variants
+	})
 
-		return newFood, nil
-	}
+	return nil
+}
 
+func (s *foodService) FindOrCreateCustomFood(input models.NewCustomFoodInput, user models.User) (*models.CustomFood, error) {
+	food, _, err := s.findOrCreateCustomFood(input.Name, user.ID)
 	if err != nil {
 		return nil, err
 	}
+	return food, nil
+}
 
-	err = s.foodRepo.AddUserToCustomFood(existingFood.ID, user.ID)
+// custom 음식 생성은 항상 여기를 거침
+// 존재 확인과 생성을 DB의 upsert 한 번으로 처리하므로 동시에 같은 이름이 들어와도 하나만 만들어짐
+func (s *foodService) findOrCreateCustomFood(name string, userID primitive.ObjectID) (*models.CustomFood, bool, error) {
+	food, created, err := s.foodRepo.FindOrCreateCustomFood(context.TODO(), name, []primitive.ObjectID{userID})
 	if err != nil {
-		return nil, err
-	}
-
-	alreadyExists := false
-	for _, uid := range existingFood.UsingUserIDs {
-		if uid == user.ID {
-			alreadyExists = true
-			break
-		}
+		return nil, false, err
 	}
 
-	if !alreadyExists {
-		existingFood.UsingUserIDs = append(existingFood.UsingUserIDs, user.ID)
+	// custom 음식 change stream이 살아 있으면 같은 변경이 그대로 들어옴
+	// 여기서 또 쓰면, 그 사이 다른 유저가 추가되어 스트림이 먼저 반영한 using_user_ids를 예전 값으로 덮어쓸 수 있음
+	if !s.changeStreamActive(customFoodStream) {
+		s.UpsertCustomFoodCache(food)
 	}
-
-	return existingFood, nil
+	return food, created, nil
 }
 
-func (s *foodService) GetMainFeedFoods(foodType, speed string, foodCount int) ([]*models.StandardFood, error) {
-	s.cacheLock.RLock()
-	defer s.cacheLock.RUnlock()
-
+// 메인 피드 후보: 해당 type/speed의 보관되지 않은 음식들
+func (s *foodService) GetMainFeedCandidates(foodType, speed string) []*models.StandardFood {
 	candidates := make([]*models.StandardFood, 0)
-	for _, food := range s.standardFoodCache {
-		if food.Type == foodType && food.Speed == speed {
+	for _, food := range s.cache.standardFoodsByTypeAndSpeed(foodType, speed) {
+		if !food.Archived {
 			candidates = append(candidates, food)
 		}
 	}
-
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	r.Shuffle(len(candidates), func(i, j int) {
-		candidates[i], candidates[j] = candidates[j], candidates[i]
-	})
-
-	resultList := make([]*models.StandardFood, 0, foodCount)
-	usedCategories := make(map[string]bool)
-
-	for _, food := range candidates {
-		if len(resultList) >= foodCount {
-			break
-		}
-		if len(food.Categories) == 0 {
-			resultList = append(resultList, food)
-			continue
-		}
-
-		uniqueCategory := true
-		for _, category := range food.Categories {
-			if usedCategories[category] {
-				uniqueCategory = false
-				break
-			}
-		}
-		if uniqueCategory {
-			resultList = append(resultList, food)
-			for _, category := range food.Categories {
-				usedCategories[category] = true
-			}
-		}
-	}
-
-	log.Printf("Selected %d main feed foods", len(resultList))
-
-	return resultList, nil
+	return candidates
 }
 
 type matchCandidates struct {
@@ -231,8 +209,8 @@ func (s *foodService) ValidateFoods(names []string, userID primitive.ObjectID) (
 	for _, name := range names {
 		result := models.ValidationResult{OriginalName: name}
 
-		standardFood, err := s.foodRepo.FindStandardFoodByName(name)
-		if err == nil {
+		standardFood, exists := s.cache.standardFoodByName(name)
+		if exists && !standardFood.Archived {
 			result.Status = "ok"
 			result.OkOutput = &models.ValidationOutput{
 				ID:   standardFood.ID,
@@ -243,8 +221,8 @@ func (s *foodService) ValidateFoods(names []string, userID primitive.ObjectID) (
 			continue
 		}
 
-		customFood, err := s.foodRepo.FindCustomFoodByName(name)
-		if err == nil {
+		customFood, exists := s.cache.customFoodByName(name)
+		if exists {
 			result.Status = "ok"
 			result.OkOutput = &models.ValidationOutput{
 				ID:   customFood.ID,
@@ -255,75 +233,98 @@ func (s *foodService) ValidateFoods(names []string, userID primitive.ObjectID) (
 			continue
 		}
 
-		var candidates []matchCandidates
+		// 한/영 전환을 잊고 친 입력이나 로마자 입력이면 변형한 형태도 함께 비교
+		queries := matchQueries(name)
 
-		s.cacheLock.RLock()
-
-		for _, food := range s.standardFoodCache {
-			score := utils.Score(name, food.Name)
-			if score >= similarityThreshold {
-				candidates = append(candidates, matchCandidates{
-					Score: score,
-					Output: models.ValidationOutput{
-						ID:   food.ID,
-						Name: food.Name,
-						Type: "standard",
-					},
-				})
+		bestScores := make(map[primitive.ObjectID]matchCandidates)
+		addCandidate := func(score float64, output models.ValidationOutput) {
+			if score < similarityThreshold {
+				return
+			}
+			if existing, exists := bestScores[output.ID]; exists && existing.Score >= score {
+				return
 			}
+			bestScores[output.ID] = matchCandidates{Score: score, Output: output}
 		}
 
-		for _, food := range s.customFoodCache {
-			score := utils.Score(name, food.Name)
-			if score >= similarityThreshold {
-				candidates = append(candidates, matchCandidates{
-					Score: score,
-					Output: models.ValidationOutput{
-						ID:   food.ID,
-						Name: food.Name,
-						Type: "custom",
-					},
+		// "bibimbap"처럼 로마자로 정확히 같은 standard 음식
+		romanizedMatches := make(map[primitive.ObjectID]models.ValidationOutput)
+
+		// 전체를 훑지 않고 n-gram 색인으로 추린 후보에 대해서만 점수를 계산
+		for _, query := range queries {
+			s.cache.visitStandardCandidates(query, func(food *models.StandardFood) {
+				if food.Archived {
+					return
+				}
+				output := models.ValidationOutput{
+					ID:   food.ID,
+					Name: food.Name,
+					Type: "standard",
+				}
+				if standardFoodRomanizedEqual(name, food) {
+					romanizedMatches[food.ID] = output
+				}
+				addCandidate(standardFoodScore(query, food), output)
+			})
+
+			s.cache.visitCustomCandidates(query, func(food *models.CustomFood) {
+				addCandidate(foodNameScore(query, food.Name), models.ValidationOutput{
+					ID:   food.ID,
+					Name: food.Name,
+					Type: "custom",
 				})
+			})
+		}
+
+		// 로마자 표기가 같은 음식이 하나뿐이면 확정, 여러 개면 제안으로 넘김 (갈비/칼비처럼 표기가 겹치는 경우)
+		if len(romanizedMatches) == 1 {
+			for _, output := range romanizedMatches {
+				result.Status = "ok"
+				result.OkOutput = &output
 			}
+			results = append(results, result)
+			continue
 		}
 
-		s.cacheLock.RUnlock()
+		candidates := make([]matchCandidates, 0, len(bestScores))
+		for _, candidate := range bestScores {
+			candidates = append(candidates, candidate)
+		}
 
 		if len(candidates) == 0 {
-			s.cacheLock.Lock()
-
-			// TODO : Lock 걸면서 중복 생성됐는지 이중체크
-
-			newID := primitive.NewObjectID()
-			newCustomFood := &models.CustomFood{
-				ID:           newID,
-				Name:         name,
-				UsingUserIDs: []primitive.ObjectID{userID},
-				CreatedAt:    time.Now(),
-			}
-
-			err := s.foodRepo.SaveCustomFood(newCustomFood)
+			customFood, created, err := s.findOrCreateCustomFood(name, userID)
 			if err != nil {
-				s.cacheLock.Unlock()
 				return nil, err
 			}
 
-			s.customFoodCache = append(s.customFoodCache, newCustomFood)
+			// 그사이 다른 요청이 같은 이름을 먼저 만들었으면 그 음식으로 확정
+			if !created {
+				result.Status = "ok"
+				result.OkOutput = &models.ValidationOutput{
+					ID:   customFood.ID,
+					Name: customFood.Name,
+					Type: "custom",
+				}
+				results = append(results, result)
+				continue
+			}
 
 			result.Status = "new"
 			result.NewOutput = &models.ValidationOutput{
-				ID:   newID,
-				Name: name,
+				ID:   customFood.ID,
+				Name: customFood.Name,
 				Type: "new",
 			}
 			results = append(results, result)
-
-			s.cacheLock.Unlock()
 			continue
 		}
 
+		// 색인 후보는 순서가 정해져 있지 않으므로 점수가 같으면 이름순으로 정렬
 		sort.Slice(candidates, func(i, j int) bool {
-			return candidates[i].Score > candidates[j].Score
+			if candidates[i].Score != candidates[j].Score {
+				return candidates[i].Score > candidates[j].Score
+			}
+			return candidates[i].Output.Name < candidates[j].Output.Name
 		})
 
 		limit := min(len(candidates), maxSuggestions)
@@ -338,78 +339,246 @@ func (s *foodService) ValidateFoods(names []string, userID primitive.ObjectID) (
 	return results, nil
 }
 
-func (s *foodService) UpdateCreatedReviewStats(foodIDs []primitive.ObjectID, rating int) error {
-	err := s.foodRepo.UpdateCreatedReviewStats(foodIDs, rating)
-	if err != nil {
-		return err
+// 매칭에 쓰는 standard 음식의 이름들 (이름, 별칭, 영문 이름)
+func standardFoodNames(food *models.StandardFood) []string {
+	names := append([]string{food.Name}, food.Aliases...)
+	if food.EnglishName != "" {
+		names = append(names, food.EnglishName)
 	}
+	return names
+}
 
-	s.cacheLock.Lock()
-	defer s.cacheLock.Unlock()
-
-	for _, foodID := range foodIDs {
-		err := s.SyncRatingStatsCache(foodID, 0, rating)
-		if err != nil {
-			return err
+// 검색어 변형 목록: 원래 입력, 한/영 전환을 잊고 친 입력을 한글로 바꾼 것, 영문 입력의 로마자 비교용 키
+func matchQueries(name string) []string {
+	queries := []string{name}
+	if converted, ok := utils.ConvertQwertyToHangul(name); ok {
+		queries = append(queries, converted)
+	}
+	if utils.ContainsLatin(name) {
+		if key := utils.RomanizedKey(name); key != "" && key != strings.ToLower(name) {
+			queries = append(queries, key)
 		}
 	}
-
-	return nil
+	return queries
 }
 
-func (s *foodService) UpdateModifiedReviewStats(foodIDs []primitive.ObjectID, oldRating, newRating int) error {
-	err := s.foodRepo.UpdateModifiedReviewStats(foodIDs, oldRating, newRating)
-	if err != nil {
-		return err
+// 음식 이름/별칭/영문 이름 중 가장 비슷한 쪽의 점수를 사용
+// 영문 입력은 한글 이름의 로마자 표기와도 비교함
+func standardFoodScore(name string, food *models.StandardFood) float64 {
+	best := 0.0
+	for _, foodName := range standardFoodNames(food) {
+		best = max(best, foodNameScore(name, foodName))
 	}
+	return best
+}
 
-	s.cacheLock.Lock()
-	defer s.cacheLock.Unlock()
+func foodNameScore(name, foodName string) float64 {
+	return max(utils.MatchScore(name, foodName), utils.RomanizedScore(name, foodName))
+}
 
-	for _, foodID := range foodIDs {
-		err := s.SyncRatingStatsCache(foodID, oldRating, newRating)
-		if err != nil {
-			return err
+// 영문 입력이 로마자 표기 기준으로 음식 이름/별칭/영문 이름 중 하나와 같은지 여부
+func standardFoodRomanizedEqual(name string, food *models.StandardFood) bool {
+	for _, foodName := range standardFoodNames(food) {
+		if utils.RomanizedEqual(name, foodName) {
+			return true
 		}
 	}
+	return false
+}
 
-	return nil
+const layoutCorrectionWeight = 0.95
+
+type weightedQuery struct {
+	text   string
+	weight float64
 }
 
-func (s *foodService) UpdateLikeStats(foodID primitive.ObjectID, increment int) error {
-	var err error
-	if increment > 0 {
-		err = s.foodRepo.IncrementLikeCount(foodID)
-	} else if increment < 0 {
-		err = s.foodRepo.DecrementLikeCount(foodID)
+// standard와 custom 음식을 함께 검색
+// type/speed/category 필터는 standard 음식에만 있는 속성이므로 필터를 주면 custom 음식은 제외됨
+func (s *foodService) SearchFoods(query models.FoodSearchQuery) (*models.FoodSearchPage, error) {
+	filtered := query.Type != "" || query.Speed != "" || query.Category != ""
+
+	// 한/영 전환을 잊고 친 검색어는 한글로 바꿔서도 찾되, 그대로 친 검색어의 일치보다는 낮게 둠
+	queries := []weightedQuery{{text: query.Query, weight: 1}}
+	if converted, ok := utils.ConvertQwertyToHangul(query.Query); ok {
+		queries = append(queries, weightedQuery{text: converted, weight: layoutCorrectionWeight})
 	}
-	if err != nil {
-		return err
+
+	bestResults := make(map[primitive.ObjectID]models.FoodSearchResult)
+	addResult := func(result models.FoodSearchResult) {
+		if result.Score == 0 {
+			return
+		}
+		if existing, exists := bestResults[result.ID]; exists && existing.Score >= result.Score {
+			return
+		}
+		bestResults[result.ID] = result
 	}
 
-	s.cacheLock.Lock()
-	defer s.cacheLock.Unlock()
+	for _, q := range queries {
+		s.cache.visitStandardCandidates(q.text, func(food *models.StandardFood) {
+			if food.Archived || !matchesSearchFilters(food, query) {
+				return
+			}
 
-	for _, food := range s.standardFoodCache {
-		if food.ID == foodID {
-			food.LikeCount += increment
-			if food.LikeCount < 0 {
-				food.LikeCount = 0
+			score, matchedName := 0.0, food.Name
+			for _, foodName := range standardFoodNames(food) {
+				if nameScore := foodNameSearchScore(q.text, foodName); nameScore > score {
+					score, matchedName = nameScore, foodName
+				}
 			}
-			break
+
+			addResult(models.FoodSearchResult{
+				ID:          food.ID,
+				Name:        food.Name,
+				Type:        "standard",
+				MatchedName: matchedName,
+				Score:       score * q.weight,
+				Food:        food,
+			})
+		})
+
+		if filtered {
+			continue
+		}
+		s.cache.visitCustomCandidates(q.text, func(food *models.CustomFood) {
+			addResult(models.FoodSearchResult{
+				ID:          food.ID,
+				Name:        food.Name,
+				Type:        "custom",
+				MatchedName: food.Name,
+				Score:       foodNameSearchScore(q.text, food.Name) * q.weight,
+			})
+		})
+	}
+
+	// 결과에 담는 음식은 캐시 밖으로 나가므로 복사본으로 바꿈
+	for id, result := range bestResults {
+		if result.Food != nil {
+			result.Food = copyStandardFood(result.Food)
+			bestResults[id] = result
 		}
 	}
 
-	return nil
+	results := make([]models.FoodSearchResult, 0, len(bestResults))
+	for _, result := range bestResults {
+		results = append(results, result)
+	}
+
+	// 점수가 같으면 standard 음식, 그다음 이름이 짧은 순서
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Type != b.Type {
+			return a.Type == "standard"
+		}
+		if len(a.Name) != len(b.Name) {
+			return len(a.Name) < len(b.Name)
+		}
+		return a.Name < b.Name
+	})
+
+	page := &models.FoodSearchPage{
+		Results: []models.FoodSearchResult{},
+		Total:   len(results),
+	}
+	if query.Offset < len(results) {
+		end := min(query.Offset+query.Limit, len(results))
+		page.Results = results[query.Offset:end]
+		if end < len(results) {
+			page.NextOffset = &end
+		}
+	}
+
+	return page, nil
 }
 
-func (s *foodService) SyncRatingStatsCache(foodID primitive.ObjectID, oldRating, newRating int) error {
-	for _, food := range s.standardFoodCache {
-		if food.ID == foodID {
-			food.TotalRating = food.TotalRating - oldRating + newRating
-			break
+// 영문 검색어는 한글 이름의 로마자 표기로도 자동완성/오타 매칭이 되도록 함
+func foodNameSearchScore(query, foodName string) float64 {
+	return max(utils.SearchScore(query, foodName), utils.RomanizedSearchScore(query, foodName))
+}
+
+func matchesSearchFilters(food *models.StandardFood, query models.FoodSearchQuery) bool {
+	if query.Type != "" && food.Type != query.Type {
+		return false
+	}
+	if query.Speed != "" && food.Speed != query.Speed {
+		return false
+	}
+	if query.Category != "" {
+		for _, category := range food.Categories {
+			if category == query.Category {
+				return true
+			}
 		}
+		return false
 	}
+	return true
+}
+
+func (s *foodService) ReloadStandardFoodCache() error {
+	s.standardReloadLock.Lock()
+	defer s.standardReloadLock.Unlock()
 
+	allStandardFoods, err := s.foodRepo.GetAllStandardFoods()
+	if err != nil {
+		return err
+	}
+
+	s.cache.replaceStandardFoods(allStandardFoods)
+
+	log.Printf("Reloaded %d standard foods into cache", len(allStandardFoods))
 	return nil
 }
+
+// 별칭/이름 중복 검사용. 매번 DB에서 전체 음식을 읽지 않도록 캐시의 이름 색인을 복사해서 씀
+func (s *foodService) standardFoodNameOwners() foodNameOwners {
+	return s.cache.standardFoodNameOwners()
+}
+
+// DB에 반영된 음식 정보를 캐시에 덮어쓰거나, 없으면 추가
+func (s *foodService) UpsertStandardFoodCache(food *models.StandardFood) {
+	s.cache.upsertStandardFood(food)
+}
+
+func (s *foodService) RemoveStandardFoodCache(foodID primitive.ObjectID) {
+	s.cache.removeStandardFood(foodID)
+}
+
+func (s *foodService) UpsertCustomFoodCache(food *models.CustomFood) {
+	s.cache.upsertCustomFood(food)
+}
+
+func (s *foodService) RemoveCustomFoodCache(foodID primitive.ObjectID) {
+	s.cache.removeCustomFood(foodID)
+}
+
+// DB 트랜잭션이 커밋된 뒤에 호출해 캐시에 통계 변화를 반영
+// standard 음식 change stream이 살아 있으면 바뀐 문서가 그대로 들어오므로, 여기서 또 더하면 두 번 반영됨
+func (s *foodService) SyncReviewStatsCache(diffs []models.ReviewStatsDiff) {
+	if s.changeStreamActive(standardFoodStream) {
+		return
+	}
+
+	for _, diff := range diffs {
+		s.cache.updateStandardFood(diff.FoodID, func(food *models.StandardFood) {
+			food.ReviewCount += diff.ReviewCount
+			food.TotalRating += diff.TotalRating
+		})
+	}
+}
+
+func (s *foodService) SyncLikeStatsCache(foodID primitive.ObjectID, increment int) {
+	if s.changeStreamActive(standardFoodStream) {
+		return
+	}
+
+	s.cache.updateStandardFood(foodID, func(food *models.StandardFood) {
+		food.LikeCount += increment
+		if food.LikeCount < 0 {
+			food.LikeCount = 0
+		}
+	})
+}
@@ -0,0 +1,133 @@
+// api/services/image_service.go
+
+// 이미지를 처리해 원본(정제본)과 썸네일/중간 크기 변형을 저장소에 기록
+
+package services
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"path"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/seojoonrp/bapddang-server/models"
+	"github.com/seojoonrp/bapddang-server/storage"
+	"github.com/seojoonrp/bapddang-server/utils"
+)
+
+const processedImageContentType = "image/jpeg"
+
+type ImageService interface {
+	NewImageKey(prefix string) string
+	StoreImage(key string, r io.Reader) (*models.StoredImage, error)
+	ProcessStoredImage(key string) (*models.StoredImage, error)
+	DescribeImage(key string) *models.StoredImage
+	DescribeProcessedUpload(key string) *models.StoredImage
+	DeleteImage(key string)
+}
+
+type imageService struct {
+	objectStorage storage.ObjectStorage
+}
+
+func NewImageService(objectStorage storage.ObjectStorage) ImageService {
+	return &imageService{objectStorage: objectStorage}
+}
+
+func (s *imageService) NewImageKey(prefix string) string {
+	return prefix + uuid.NewString() + ".jpg"
+}
+
+// reviews/u/abc.jpg -> reviews/u/abc_thumb.jpg
+func variantKey(key string, spec utils.ImageVariantSpec) string {
+	return strings.TrimSuffix(key, path.Ext(key)) + "_" + spec.Name + ".jpg"
+}
+
+func (s *imageService) StoreImage(key string, r io.Reader) (*models.StoredImage, error) {
+	if err := s.storeProcessedImage(key, key, r); err != nil {
+		return nil, err
+	}
+	return s.DescribeImage(key), nil
+}
+
+// 클라이언트가 presigned URL로 올린 원본을 읽어 정제본을 별도의 key(_original)에 저장하고 원본은 지움
+// presigned URL은 만료 전까지 다시 쓸 수 있으므로, 정제본을 같은 key에 덮어쓰면 처리 후에 올린 원본(EXIF 포함)이 그대로 노출됨
+func (s *imageService) ProcessStoredImage(key string) (*models.StoredImage, error) {
+	raw, err := s.objectStorage.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	defer raw.Close()
+
+	if err := s.storeProcessedImage(key, variantKey(key, utils.OriginalImageSpec), raw); err != nil {
+		return nil, err
+	}
+	if err := s.objectStorage.Delete(key); err != nil {
+		log.Printf("Failed to delete raw upload %s: %v", key, err)
+	}
+
+	return s.DescribeProcessedUpload(key), nil
+}
+
+// 정제본은 originalKey에, 썸네일/중간 크기는 key 기준 이름으로 저장
+func (s *imageService) storeProcessedImage(key, originalKey string, r io.Reader) error {
+	processed, err := utils.ProcessImage(r)
+	if err != nil {
+		return err
+	}
+
+	writes := []struct {
+		key  string
+		data []byte
+	}{
+		{variantKey(key, utils.ThumbnailImageSpec), processed.Thumbnail},
+		{variantKey(key, utils.MediumImageSpec), processed.Medium},
+		{originalKey, processed.Original},
+	}
+	for _, write := range writes {
+		if err := s.objectStorage.Put(write.key, bytes.NewReader(write.data), processedImageContentType); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *imageService) DescribeImage(key string) *models.StoredImage {
+	return &models.StoredImage{
+		Key: key,
+		URL: s.objectStorage.PublicURL(key),
+		Variants: models.ImageVariants{
+			Thumbnail: s.objectStorage.PublicURL(variantKey(key, utils.ThumbnailImageSpec)),
+			Medium:    s.objectStorage.PublicURL(variantKey(key, utils.MediumImageSpec)),
+		},
+	}
+}
+
+// ProcessStoredImage로 처리한 업로드의 이미지 정보 (원본 URL이 정제본 key를 가리킴)
+func (s *imageService) DescribeProcessedUpload(key string) *models.StoredImage {
+	image := s.DescribeImage(key)
+	image.URL = s.objectStorage.PublicURL(variantKey(key, utils.OriginalImageSpec))
+	return image
+}
+
+// 원본과 변형을 모두 삭제. 실패해도 호출한 쪽 처리에는 영향이 없도록 로그만 남김
+func (s *imageService) DeleteImage(key string) {
+	if key == "" {
+		return
+	}
+
+	keys := []string{
+		key,
+		variantKey(key, utils.OriginalImageSpec),
+		variantKey(key, utils.ThumbnailImageSpec),
+		variantKey(key, utils.MediumImageSpec),
+	}
+	for _, k := range keys {
+		if err := s.objectStorage.Delete(k); err != nil {
+			log.Printf("Failed to delete image %s: %v", k, err)
+		}
+	}
+}
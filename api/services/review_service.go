@@ -3,31 +3,43 @@
 package services
 
 import (
+	"context"
 	"time"
 
 	"github.com/seojoonrp/bapddang-server/api/repositories"
 	"github.com/seojoonrp/bapddang-server/models"
+	"github.com/seojoonrp/bapddang-server/utils"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type ReviewService interface {
 	CreateReview(input models.ReviewInput, user models.User) (*models.Review, error)
-	UpdateReview(reviewID primitive.ObjectID, input models.ReviewInput, user models.User) (*models.Review, int, error)
+	UpdateReview(reviewID primitive.ObjectID, input models.ReviewInput, user models.User) (*models.Review, error)
+	DeleteReview(reviewID primitive.ObjectID, user models.User) (*models.Review, error)
 	GetMyReviewsByDay(userID primitive.ObjectID, day int) ([]models.Review, error)
+	GetMyReviewHistory(query models.ReviewHistoryQuery) (*models.ReviewHistoryPage, error)
+	BackfillMealTimes(dryRun bool) (*models.MealTimeBackfillReport, error)
 }
 
 type reviewService struct {
-	reviewRepo repositories.ReviewRepository
-	foodRepo   repositories.FoodRepository
+	reviewRepo    repositories.ReviewRepository
+	foodRepo      repositories.FoodRepository
+	foodService   FoodService
+	uploadService UploadService
+	txManager     repositories.TransactionManager
 }
 
-func NewReviewService(reviewRepo repositories.ReviewRepository, foodRepo repositories.FoodRepository) ReviewService {
+func NewReviewService(reviewRepo repositories.ReviewRepository, foodRepo repositories.FoodRepository, foodService FoodService, uploadService UploadService, txManager repositories.TransactionManager) ReviewService {
 	return &reviewService{
-		reviewRepo: reviewRepo,
-		foodRepo:   foodRepo,
+		reviewRepo:    reviewRepo,
+		foodRepo:      foodRepo,
+		foodService:   foodService,
+		uploadService: uploadService,
+		txManager:     txManager,
 	}
 }
 
+// meal_time은 끼니 값으로 바꿀 수 있으면 바꿔 저장함 (utils.CanonicalMealTime)
 func (s *reviewService) CreateReview(input models.ReviewInput, user models.User) (*models.Review, error) {
 	newReview := models.Review{
 		ID:        primitive.NewObjectID(),
@@ -35,9 +47,8 @@ func (s *reviewService) CreateReview(input models.ReviewInput, user models.User)
 		Name:      input.Name,
 		Foods:     input.Foods,
 		Speed:     input.Speed,
-		MealTime:  input.MealTime,
+		MealTime:  utils.CanonicalMealTime(input.MealTime),
 		Tags:      input.Tags,
-		ImageURL:  input.ImageURL,
 		Comment:   input.Comment,
 		Rating:    input.Rating,
 		Day:       user.Day,
@@ -45,37 +56,256 @@ func (s *reviewService) CreateReview(input models.ReviewInput, user models.User)
 		UpdatedAt: time.Now(),
 	}
 
-	err := s.reviewRepo.SaveReview(&newReview)
+	if input.ImageKey != "" {
+		image, err := s.uploadService.ResolveReviewImage(user.ID, input.ImageKey)
+		if err != nil {
+			return nil, err
+		}
+		newReview.ImageKey = image.Key
+		newReview.ImageURL = image.URL
+		newReview.ImageVariants = &image.Variants
+	}
+
+	diffs := calculateReviewStatsDiffs(nil, 0, newReview.Foods, newReview.Rating)
+
+	err := s.txManager.WithTransaction(func(ctx context.Context) error {
+		if err := s.reviewRepo.SaveReview(ctx, &newReview); err != nil {
+			return err
+		}
+		if newReview.ImageKey != "" {
+			if err := s.uploadService.AttachUpload(ctx, newReview.ImageKey); err != nil {
+				return err
+			}
+		}
+		return s.foodRepo.UpdateReviewStats(ctx, diffs)
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	s.foodService.SyncReviewStatsCache(diffs)
+
 	return &newReview, nil
 }
 
-func (s *reviewService) UpdateReview(reviewID primitive.ObjectID, input models.ReviewInput, user models.User) (*models.Review, int, error) {
-	existingReview, err := s.reviewRepo.FindByIDAndUserID(reviewID, user.ID)
+func (s *reviewService) UpdateReview(reviewID primitive.ObjectID, input models.ReviewInput, user models.User) (*models.Review, error) {
+	currentReview, err := s.reviewRepo.FindByIDAndUserID(context.TODO(), reviewID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 이미 이 리뷰에 연결된 이미지를 그대로 두는 경우는 다시 확인하지 않음
+	var image *models.StoredImage
+	if input.ImageKey != "" && input.ImageKey != currentReview.ImageKey {
+		resolvedImage, err := s.uploadService.ResolveReviewImage(user.ID, input.ImageKey)
+		if err != nil {
+			return nil, err
+		}
+		image = resolvedImage
+	}
+
+	var updatedReview *models.Review
+	var diffs []models.ReviewStatsDiff
+	var releasedImageKey string
+
+	err = s.txManager.WithTransaction(func(ctx context.Context) error {
+		existingReview, err := s.reviewRepo.FindByIDAndUserID(ctx, reviewID, user.ID)
+		if err != nil {
+			return err
+		}
+
+		oldFoods := existingReview.Foods
+		oldRating := existingReview.Rating
+
+		releasedImageKey = ""
+		if input.ImageKey != existingReview.ImageKey {
+			if input.ImageKey != "" {
+				// 위에서 읽은 뒤 리뷰의 이미지가 바뀌어 확인하지 않은 key가 들어온 경우
+				if image == nil {
+					return ErrInvalidImageKey
+				}
+				if err := s.uploadService.AttachUpload(ctx, input.ImageKey); err != nil {
+					return err
+				}
+			}
+			releasedImageKey = existingReview.ImageKey
+			existingReview.ImageKey = ""
+			existingReview.ImageURL = ""
+			existingReview.ImageVariants = nil
+			if image != nil {
+				existingReview.ImageKey = image.Key
+				existingReview.ImageURL = image.URL
+				existingReview.ImageVariants = &image.Variants
+			}
+		}
+
+		existingReview.Name = input.Name
+		existingReview.Foods = input.Foods
+		existingReview.Speed = input.Speed
+		existingReview.MealTime = utils.CanonicalMealTime(input.MealTime)
+		existingReview.Tags = input.Tags
+		existingReview.Comment = input.Comment
+		existingReview.Rating = input.Rating
+		existingReview.UpdatedAt = time.Now()
+
+		if err := s.reviewRepo.UpdateReview(ctx, existingReview); err != nil {
+			return err
+		}
+
+		diffs = calculateReviewStatsDiffs(oldFoods, oldRating, existingReview.Foods, existingReview.Rating)
+		if err := s.foodRepo.UpdateReviewStats(ctx, diffs); err != nil {
+			return err
+		}
+
+		updatedReview = existingReview
+		return nil
+	})
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
-	oldRating := existingReview.Rating
+	s.foodService.SyncReviewStatsCache(diffs)
+	s.uploadService.ReleaseUpload(releasedImageKey)
+
+	return updatedReview, nil
+}
+
+func (s *reviewService) DeleteReview(reviewID primitive.ObjectID, user models.User) (*models.Review, error) {
+	var deletedReview *models.Review
+	var diffs []models.ReviewStatsDiff
 
-	existingReview.MealTime = input.MealTime
-	existingReview.Tags = input.Tags
-	existingReview.ImageURL = input.ImageURL
-	existingReview.Comment = input.Comment
-	existingReview.Rating = input.Rating
-	existingReview.UpdatedAt = time.Now()
+	err := s.txManager.WithTransaction(func(ctx context.Context) error {
+		review, err := s.reviewRepo.DeleteByIDAndUserID(ctx, reviewID, user.ID)
+		if err != nil {
+			return err
+		}
 
-	err = s.reviewRepo.UpdateReview(existingReview)
+		diffs = calculateReviewStatsDiffs(review.Foods, review.Rating, nil, 0)
+		if err := s.foodRepo.UpdateReviewStats(ctx, diffs); err != nil {
+			return err
+		}
+
+		deletedReview = review
+		return nil
+	})
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
-	return existingReview, oldRating, nil
+	s.foodService.SyncReviewStatsCache(diffs)
+	s.uploadService.ReleaseUpload(deletedReview.ImageKey)
+
+	return deletedReview, nil
 }
 
 func (s *reviewService) GetMyReviewsByDay(userID primitive.ObjectID, day int) ([]models.Review, error) {
 	return s.reviewRepo.FindByUserIDAndDay(userID, day)
 }
+
+func (s *reviewService) GetMyReviewHistory(query models.ReviewHistoryQuery) (*models.ReviewHistoryPage, error) {
+	reviews, err := s.reviewRepo.FindHistory(query)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.ReviewHistoryPage{Reviews: reviews}
+	if len(reviews) > query.Limit {
+		page.Reviews = reviews[:query.Limit]
+		page.NextCursor = page.Reviews[query.Limit-1].ID.Hex()
+	}
+
+	return page, nil
+}
+
+// 리뷰 변경 전후의 음식 목록과 별점을 비교해 음식별 review_count / total_rating 변화량을 계산
+// 별점이 1~5 범위를 벗어난 리뷰는 통계에 포함하지 않음
+func calculateReviewStatsDiffs(oldFoods []models.ReviewedFoodItem, oldRating int, newFoods []models.ReviewedFoodItem, newRating int) []models.ReviewStatsDiff {
+	diffMap := make(map[primitive.ObjectID]*models.ReviewStatsDiff)
+	order := make([]primitive.ObjectID, 0)
+
+	apply := func(foods []models.ReviewedFoodItem, rating, sign int) {
+		if !isCountedRating(rating) {
+			return
+		}
+		for _, foodID := range standardFoodIDs(foods) {
+			diff, exists := diffMap[foodID]
+			if !exists {
+				diff = &models.ReviewStatsDiff{FoodID: foodID}
+				diffMap[foodID] = diff
+				order = append(order, foodID)
+			}
+			diff.ReviewCount += sign
+			diff.TotalRating += sign * rating
+		}
+	}
+
+	apply(oldFoods, oldRating, -1)
+	apply(newFoods, newRating, 1)
+
+	diffs := make([]models.ReviewStatsDiff, 0, len(order))
+	for _, foodID := range order {
+		diff := diffMap[foodID]
+		if diff.ReviewCount == 0 && diff.TotalRating == 0 {
+			continue
+		}
+		diffs = append(diffs, *diff)
+	}
+
+	return diffs
+}
+
+func isCountedRating(rating int) bool {
+	return rating > 0 && rating <= 5
+}
+
+// 리뷰의 음식 목록에서 중복 없이 standard 음식 ID만 추출
+func standardFoodIDs(foods []models.ReviewedFoodItem) []primitive.ObjectID {
+	ids := make([]primitive.ObjectID, 0, len(foods))
+	seen := make(map[primitive.ObjectID]bool)
+	for _, food := range foods {
+		if food.FoodType != "standard" || seen[food.FoodID] {
+			continue
+		}
+		seen[food.FoodID] = true
+		ids = append(ids, food.FoodID)
+	}
+	return ids
+}
+
+// 끼니 값이 아닌 meal_time을 NormalizeMealTime 결과로 바꿈. 알 수 없는 값은 건드리지 않고 보고만 함
+func (s *reviewService) BackfillMealTimes(dryRun bool) (*models.MealTimeBackfillReport, error) {
+	counts, err := s.reviewRepo.CountByMealTime()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.MealTimeBackfillReport{
+		Changes: []models.MealTimeBackfillChange{},
+		Unknown: []models.MealTimeCount{},
+		DryRun:  dryRun,
+	}
+
+	for _, count := range counts {
+		mealTime := utils.NormalizeMealTime(count.MealTime)
+		if mealTime == "" {
+			report.Unknown = append(report.Unknown, count)
+			continue
+		}
+		if mealTime == count.MealTime {
+			continue
+		}
+
+		change := models.MealTimeBackfillChange{From: count.MealTime, To: mealTime, Count: count.Count}
+		if !dryRun {
+			updated, err := s.reviewRepo.ReplaceMealTime(count.MealTime, mealTime)
+			if err != nil {
+				return report, err
+			}
+			change.Count = updated
+			report.UpdatedCount += updated
+		}
+		report.Changes = append(report.Changes, change)
+	}
+
+	return report, nil
+}
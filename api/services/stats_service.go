@@ -0,0 +1,109 @@
+// api/services/stats_service.go
+
+// 음식 통계(like_count, review_count, total_rating) 재계산 및 보정
+
+package services
+
+import (
+	"log"
+	"time"
+
+	"github.com/seojoonrp/bapddang-server/api/repositories"
+	"github.com/seojoonrp/bapddang-server/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type StatsService interface {
+	ReconcileFoodStats(dryRun bool) (*models.FoodStatsReport, error)
+}
+
+type statsService struct {
+	foodRepo   repositories.FoodRepository
+	reviewRepo repositories.ReviewRepository
+	userRepo   repositories.UserRepository
+}
+
+func NewStatsService(foodRepo repositories.FoodRepository, reviewRepo repositories.ReviewRepository, userRepo repositories.UserRepository) StatsService {
+	return &statsService{
+		foodRepo:   foodRepo,
+		reviewRepo: reviewRepo,
+		userRepo:   userRepo,
+	}
+}
+
+// reviews 컬렉션과 유저들의 liked_food_ids로부터 통계를 다시 계산해 저장된 값과 비교
+// dryRun이 아니면 차이가 난 음식들의 통계를 보정함
+func (s *statsService) ReconcileFoodStats(dryRun bool) (*models.FoodStatsReport, error) {
+	report := &models.FoodStatsReport{
+		Drifts:    make([]models.FoodStatsDrift, 0),
+		StartedAt: time.Now(),
+	}
+
+	foods, err := s.foodRepo.GetAllStandardFoods()
+	if err != nil {
+		return nil, err
+	}
+	reviewStats, err := s.reviewRepo.AggregateFoodReviewStats()
+	if err != nil {
+		return nil, err
+	}
+	likeStats, err := s.userRepo.AggregateFoodLikeCounts()
+	if err != nil {
+		return nil, err
+	}
+
+	actualStats := make(map[primitive.ObjectID]*models.FoodStats)
+	getActual := func(foodID primitive.ObjectID) *models.FoodStats {
+		stats, exists := actualStats[foodID]
+		if !exists {
+			stats = &models.FoodStats{FoodID: foodID}
+			actualStats[foodID] = stats
+		}
+		return stats
+	}
+	for _, stats := range reviewStats {
+		actual := getActual(stats.FoodID)
+		actual.ReviewCount = stats.ReviewCount
+		actual.TotalRating = stats.TotalRating
+	}
+	for _, stats := range likeStats {
+		getActual(stats.FoodID).LikeCount = stats.LikeCount
+	}
+
+	for _, food := range foods {
+		stored := models.FoodStats{
+			FoodID:      food.ID,
+			LikeCount:   food.LikeCount,
+			ReviewCount: food.ReviewCount,
+			TotalRating: food.TotalRating,
+		}
+		actual := models.FoodStats{FoodID: food.ID}
+		if stats, exists := actualStats[food.ID]; exists {
+			actual = *stats
+		}
+
+		if stored != actual {
+			report.Drifts = append(report.Drifts, models.FoodStatsDrift{
+				FoodID: food.ID,
+				Name:   food.Name,
+				Stored: stored,
+				Actual: actual,
+			})
+		}
+	}
+
+	report.CheckedCount = len(foods)
+	report.DriftCount = len(report.Drifts)
+
+	if !dryRun && report.DriftCount > 0 {
+		if err := s.foodRepo.CorrectFoodStats(report.Drifts); err != nil {
+			return nil, err
+		}
+		report.Fixed = true
+	}
+
+	report.FinishedAt = time.Now()
+	log.Printf("Reconciled food stats: checked %d, drifted %d, fixed %v", report.CheckedCount, report.DriftCount, report.Fixed)
+
+	return report, nil
+}
@@ -0,0 +1,197 @@
+// api/services/upload_service.go
+
+// presigned URL 기반 이미지 업로드 관리
+// 클라이언트는 발급받은 URL로 직접 업로드한 뒤 key만 서버에 전달하고,
+// 서버는 객체가 실제로 존재하는지 확인하고 정제/리사이즈한 뒤 리뷰에 연결함
+
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/seojoonrp/bapddang-server/api/repositories"
+	"github.com/seojoonrp/bapddang-server/models"
+	"github.com/seojoonrp/bapddang-server/storage"
+	"github.com/seojoonrp/bapddang-server/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	reviewImageContentType = "image/jpeg"
+	presignExpiry          = 15 * time.Minute
+	orphanUploadMaxAge     = 24 * time.Hour
+	orphanCleanupInterval  = time.Hour
+)
+
+var (
+	ErrInvalidImageKey  = errors.New("invalid image key")
+	ErrImageNotUploaded = errors.New("image not uploaded")
+	// 하나의 업로드는 리뷰 하나에만 연결됨. 두 리뷰가 같은 객체를 공유하면 한쪽을 지울 때 다른 쪽 이미지도 지워짐
+	ErrImageAlreadyAttached = errors.New("image already attached")
+)
+
+type UploadService interface {
+	CreateReviewImageUpload(userID primitive.ObjectID) (*models.PresignedUpload, error)
+	ResolveReviewImage(userID primitive.ObjectID, key string) (*models.StoredImage, error)
+	AttachUpload(ctx context.Context, key string) error
+	ReleaseUpload(key string)
+
+	CleanupOrphanedUploads() (int, error)
+	StartOrphanCleanup()
+}
+
+type uploadService struct {
+	uploadRepo    repositories.UploadRepository
+	objectStorage storage.ObjectStorage
+	imageService  ImageService
+}
+
+func NewUploadService(uploadRepo repositories.UploadRepository, objectStorage storage.ObjectStorage, imageService ImageService) UploadService {
+	return &uploadService{
+		uploadRepo:    uploadRepo,
+		objectStorage: objectStorage,
+		imageService:  imageService,
+	}
+}
+
+func reviewImagePrefix(userID primitive.ObjectID) string {
+	return "reviews/" + userID.Hex() + "/"
+}
+
+func (s *uploadService) CreateReviewImageUpload(userID primitive.ObjectID) (*models.PresignedUpload, error) {
+	key := s.imageService.NewImageKey(reviewImagePrefix(userID))
+
+	uploadURL, err := s.objectStorage.PresignPut(key, reviewImageContentType, presignExpiry)
+	if err != nil {
+		return nil, err
+	}
+
+	upload := &models.Upload{
+		ID:        primitive.NewObjectID(),
+		Key:       key,
+		UserID:    userID,
+		Status:    models.UploadStatusPending,
+		CreatedAt: time.Now(),
+	}
+	if err := s.uploadRepo.Save(upload); err != nil {
+		return nil, err
+	}
+
+	return &models.PresignedUpload{
+		Key:         key,
+		UploadURL:   uploadURL,
+		ContentType: reviewImageContentType,
+		ExpiresAt:   upload.CreatedAt.Add(presignExpiry),
+	}, nil
+}
+
+// 유저 본인에게 발급된 key인지, 아직 다른 리뷰에 연결되지 않았는지, 실제로 업로드가 끝났는지 확인한 뒤 처리된 이미지 정보를 반환
+// 처음 확인하는 업로드라면 메타데이터 제거 및 리사이즈를 거쳐 클라이언트가 업로드할 수 없는 별도의 key에 저장함
+func (s *uploadService) ResolveReviewImage(userID primitive.ObjectID, key string) (*models.StoredImage, error) {
+	if !strings.HasPrefix(key, reviewImagePrefix(userID)) {
+		return nil, ErrInvalidImageKey
+	}
+
+	upload, err := s.uploadRepo.FindByKey(key)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrInvalidImageKey
+		}
+		return nil, err
+	}
+	if upload.UserID != userID {
+		return nil, ErrInvalidImageKey
+	}
+	if upload.Status != models.UploadStatusPending {
+		return nil, ErrImageAlreadyAttached
+	}
+
+	if upload.Processed {
+		return s.imageService.DescribeProcessedUpload(key), nil
+	}
+
+	exists, err := s.objectStorage.Exists(key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrImageNotUploaded
+	}
+
+	image, err := s.imageService.ProcessStoredImage(key)
+	if err != nil {
+		if utils.IsImageValidationError(err) {
+			s.imageService.DeleteImage(key)
+		}
+		return nil, err
+	}
+	if err := s.uploadRepo.MarkProcessed(key); err != nil {
+		return nil, err
+	}
+
+	return image, nil
+}
+
+// ResolveReviewImage 이후 동시에 같은 key로 다른 리뷰가 연결되었을 수 있으므로 여기서 다시 확인함
+func (s *uploadService) AttachUpload(ctx context.Context, key string) error {
+	err := s.uploadRepo.MarkAttached(ctx, key)
+	if err == mongo.ErrNoDocuments {
+		return ErrImageAlreadyAttached
+	}
+	return err
+}
+
+// 리뷰에서 떨어져 나간 이미지를 정리. 실패해도 리뷰 처리에는 영향이 없도록 로그만 남김
+func (s *uploadService) ReleaseUpload(key string) {
+	if key == "" {
+		return
+	}
+
+	s.imageService.DeleteImage(key)
+	if err := s.uploadRepo.DeleteByKey(key); err != nil {
+		log.Printf("Failed to delete upload record %s: %v", key, err)
+	}
+}
+
+// 발급 후 일정 시간이 지나도록 리뷰에 연결되지 않은 업로드를 삭제
+func (s *uploadService) CleanupOrphanedUploads() (int, error) {
+	uploads, err := s.uploadRepo.FindPendingCreatedBefore(time.Now().Add(-orphanUploadMaxAge))
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, upload := range uploads {
+		s.imageService.DeleteImage(upload.Key)
+		if err := s.uploadRepo.DeleteByKey(upload.Key); err != nil {
+			log.Printf("Failed to delete upload record %s: %v", upload.Key, err)
+			continue
+		}
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+func (s *uploadService) StartOrphanCleanup() {
+	go func() {
+		ticker := time.NewTicker(orphanCleanupInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			deleted, err := s.CleanupOrphanedUploads()
+			if err != nil {
+				log.Printf("Failed to clean up orphaned uploads: %v", err)
+				continue
+			}
+			if deleted > 0 {
+				log.Printf("Deleted %d orphaned uploads", deleted)
+			}
+		}
+	}()
+}
@@ -45,18 +45,28 @@ type UserService interface {
 	LoginWithKakao(accessToken string) (bool, string, *models.User, error)
 	LoginWithApple(identityToken string) (bool, string, *models.User, error)
 
+	PromoteToAdmin(username string) error
+	DemoteFromAdmin(username string) error
+
 	LikeFood(userID, foodID primitive.ObjectID) (bool, error)
 	UnlikeFood(userID, foodID primitive.ObjectID) (bool, error)
 	GetLikedFoodIDs(userID primitive.ObjectID) ([]primitive.ObjectID, error)
 }
 
 type userService struct {
-	userRepo repositories.UserRepository
-	foodRepo repositories.FoodRepository
+	userRepo    repositories.UserRepository
+	foodRepo    repositories.FoodRepository
+	foodService FoodService
+	txManager   repositories.TransactionManager
 }
 
-func NewUserService(userRepo repositories.UserRepository, foodRepo repositories.FoodRepository) UserService {
-	return &userService{userRepo: userRepo, foodRepo: foodRepo}
+func NewUserService(userRepo repositories.UserRepository, foodRepo repositories.FoodRepository, foodService FoodService, txManager repositories.TransactionManager) UserService {
+	return &userService{
+		userRepo:    userRepo,
+		foodRepo:    foodRepo,
+		foodService: foodService,
+		txManager:   txManager,
+	}
 }
 
 func (s *userService) CheckUsernameExists(username string) (bool, error) {
@@ -94,6 +104,7 @@ func (s *userService) SignUp(input models.SignUpInput) (*models.User, error) {
 		Username:     input.Username,
 		Password:     string(hashedPassword),
 		LoginMethod:  models.LoginMethodEmail,
+		Role:         models.RoleUser,
 		Day:          1,
 		LikedFoodIDs: make([]primitive.ObjectID, 0),
 		CreatedAt:    time.Now(),
@@ -142,6 +153,7 @@ func (s *userService) loginWithSocial(provider string, socialID string, email st
 			Username:     targetUsername,
 			SocialID:     socialID,
 			LoginMethod:  provider,
+			Role:         models.RoleUser,
 			Day:          1,
 			LikedFoodIDs: make([]primitive.ObjectID, 0),
 			CreatedAt:    time.Now(),
@@ -263,21 +275,79 @@ func (s *userService) LoginWithApple(identityToken string) (bool, string, *model
 	return s.loginWithSocial(models.LoginMethodApple, socialID, email)
 }
 
+// username은 유저가 정하는 값이라 서버 시작 시 자동으로 부여하지 않고, 관리자 CLI(promote-admin)에서만 호출함
+func (s *userService) PromoteToAdmin(username string) error {
+	return s.updateRole(username, models.RoleAdmin)
+}
+
+func (s *userService) DemoteFromAdmin(username string) error {
+	return s.updateRole(username, models.RoleUser)
+}
+
+func (s *userService) updateRole(username, role string) error {
+	found, err := s.userRepo.UpdateRoleByUsername(username, role)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
 func (s *userService) LikeFood(userID, foodID primitive.ObjectID) (bool, error) {
-	wasAdded, err := s.userRepo.AddLikedFood(userID, foodID)
+	var wasAdded bool
+
+	err := s.txManager.WithTransaction(func(ctx context.Context) error {
+		added, err := s.userRepo.AddLikedFood(ctx, userID, foodID)
+		if err != nil {
+			return err
+		}
+		if added {
+			if err := s.foodRepo.IncrementLikeCount(ctx, foodID); err != nil {
+				return err
+			}
+		}
+
+		wasAdded = added
+		return nil
+	})
 	if err != nil {
 		return false, err
 	}
 
+	if wasAdded {
+		s.foodService.SyncLikeStatsCache(foodID, 1)
+	}
+
 	return wasAdded, nil
 }
 
 func (s *userService) UnlikeFood(userID, foodID primitive.ObjectID) (bool, error) {
-	wasRemoved, err := s.userRepo.RemoveLikedFood(userID, foodID)
+	var wasRemoved bool
+
+	err := s.txManager.WithTransaction(func(ctx context.Context) error {
+		removed, err := s.userRepo.RemoveLikedFood(ctx, userID, foodID)
+		if err != nil {
+			return err
+		}
+		if removed {
+			if err := s.foodRepo.DecrementLikeCount(ctx, foodID); err != nil {
+				return err
+			}
+		}
+
+		wasRemoved = removed
+		return nil
+	})
 	if err != nil {
 		return false, err
 	}
 
+	if wasRemoved {
+		s.foodService.SyncLikeStatsCache(foodID, -1)
+	}
+
 	return wasRemoved, nil
 }
 
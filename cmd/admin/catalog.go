@@ -0,0 +1,99 @@
+// cmd/admin/catalog.go
+
+// standard 음식 카탈로그 가져오기/내보내기 명령
+// 내보낸 파일을 그대로 import-foods에 넣으면 같은 카탈로그가 됨
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/seojoonrp/bapddang-server/api/repositories"
+	"github.com/seojoonrp/bapddang-server/api/services"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func newFoodCatalogService(db *mongo.Database) services.FoodCatalogService {
+	foodRepository := repositories.NewFoodRepository(db.Collection("standard_foods"), db.Collection("custom_foods"))
+	// 서버 프로세스의 캐시는 다음 재시작 또는 캐시 갱신 때 반영됨
+	return services.NewFoodCatalogService(foodRepository, nil)
+}
+
+func catalogFormat(format, path string) string {
+	if format != "" {
+		return format
+	}
+	if ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), "."); ext != "" {
+		return ext
+	}
+	return services.FoodCatalogFormatJSON
+}
+
+func runImportFoods(db *mongo.Database, args []string) error {
+	flags := flag.NewFlagSet("import-foods", flag.ExitOnError)
+	path := flags.String("file", "", "가져올 카탈로그 파일 경로")
+	format := flags.String("format", "", "csv 또는 json (기본값: 파일 확장자)")
+	dryRun := flags.Bool("dry-run", false, "변경 내역만 보고하고 반영하지 않음")
+	flags.Parse(args)
+
+	if *path == "" {
+		return errors.New("-file is required")
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	catalog, err := services.ParseFoodCatalog(catalogFormat(*format, *path), file)
+	if err != nil {
+		return err
+	}
+
+	report, err := newFoodCatalogService(db).ImportStandardFoods(catalog, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+
+	if len(report.Errors) > 0 {
+		return errors.New("catalog has invalid rows, nothing was imported")
+	}
+	return nil
+}
+
+func runExportFoods(db *mongo.Database, args []string) error {
+	flags := flag.NewFlagSet("export-foods", flag.ExitOnError)
+	path := flags.String("out", "", "저장할 파일 경로 (기본값: 표준 출력)")
+	format := flags.String("format", "", "csv 또는 json (기본값: 파일 확장자)")
+	flags.Parse(args)
+
+	rows, err := newFoodCatalogService(db).ExportStandardFoods()
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if *path != "" {
+		file, err := os.Create(*path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	return services.WriteFoodCatalog(catalogFormat(*format, *path), out, rows)
+}
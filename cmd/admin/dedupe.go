@@ -0,0 +1,54 @@
+// cmd/admin/dedupe.go
+
+// custom 음식 이름 unique 색인을 만들기 전에 이미 생긴 중복 custom 음식을 합치는 명령
+// 합친 뒤에는 색인까지 만들어 두므로 서버를 재시작하지 않아도 됨
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/seojoonrp/bapddang-server/api/repositories"
+	"github.com/seojoonrp/bapddang-server/api/services"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func runDedupeCustomFoods(db *mongo.Database, args []string) error {
+	flags := flag.NewFlagSet("dedupe-custom-foods", flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "합칠 묶음만 보고하고 반영하지 않음")
+	flags.Parse(args)
+
+	foodRepository := repositories.NewFoodRepository(db.Collection("standard_foods"), db.Collection("custom_foods"))
+	foodAdminService := services.NewFoodAdminService(
+		foodRepository,
+		repositories.NewFoodRedirectRepository(db.Collection("food_redirects")),
+		repositories.NewReviewRepository(db.Collection("reviews")),
+		repositories.NewUserRepository(db.Collection("users")),
+		nil,
+		repositories.NewTransactionManager(db.Client()),
+	)
+
+	report, err := foodAdminService.MergeDuplicateCustomFoods(*dryRun)
+	if report != nil {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if *dryRun {
+		return nil
+	}
+	if err := foodRepository.EnsureIndexes(); err != nil {
+		return err
+	}
+	log.Printf("Merged %d duplicate groups and created the custom food name index", len(report.Groups))
+	return nil
+}
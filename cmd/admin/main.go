@@ -0,0 +1,74 @@
+// cmd/admin/main.go
+
+// 운영용 관리자 CLI
+// 사용법: go run ./cmd/admin <command> [flags]
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/seojoonrp/bapddang-server/config"
+	"github.com/seojoonrp/bapddang-server/database"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type command struct {
+	name        string
+	description string
+	run         func(db *mongo.Database, args []string) error
+}
+
+var commands = []command{
+	{"reconcile-food-stats", "음식 통계를 리뷰/좋아요 데이터로부터 다시 계산해 보정", runReconcileFoodStats},
+	{"promote-admin", "유저에게 관리자 권한 부여", runPromoteAdmin},
+	{"import-foods", "CSV/JSON 카탈로그 파일로 standard 음식 일괄 등록", runImportFoods},
+	{"export-foods", "standard 음식 카탈로그를 CSV/JSON으로 내보내기", runExportFoods},
+	{"dedupe-custom-foods", "이름이 같은 custom 음식을 합치고 이름 unique 색인 생성", runDedupeCustomFoods},
+	{"backfill-meal-times", "리뷰의 meal_time을 끼니 값으로 통일", runBackfillMealTimes},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(2)
+	}
+
+	var selected *command
+	for i := range commands {
+		if commands[i].name == os.Args[1] {
+			selected = &commands[i]
+			break
+		}
+	}
+	if selected == nil {
+		printUsage()
+		os.Exit(2)
+	}
+
+	config.LoadConfig()
+
+	client, err := database.ConnectDB()
+	if err != nil {
+		log.Fatal("Failed to connect to DB: ", err)
+	}
+	defer client.Disconnect(context.TODO())
+
+	db := client.Database(config.AppConfig.DBName)
+
+	if err := selected.run(db, os.Args[2:]); err != nil {
+		log.Fatalf("%s failed: %v", selected.name, err)
+	}
+}
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "usage: admin <command> [flags]")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-24s %s\n", cmd.name, cmd.description)
+	}
+}
@@ -0,0 +1,34 @@
+// cmd/admin/meal_time.go
+
+// 예전 리뷰에 제각각으로 저장된 meal_time을 끼니 값(breakfast, lunch, dinner, late-night)으로 바꾸는 명령
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+
+	"github.com/seojoonrp/bapddang-server/api/repositories"
+	"github.com/seojoonrp/bapddang-server/api/services"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func runBackfillMealTimes(db *mongo.Database, args []string) error {
+	flags := flag.NewFlagSet("backfill-meal-times", flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "바뀔 값만 보고하고 반영하지 않음")
+	flags.Parse(args)
+
+	reviewRepository := repositories.NewReviewRepository(db.Collection("reviews"))
+	reviewService := services.NewReviewService(reviewRepository, nil, nil, nil, nil)
+
+	report, err := reviewService.BackfillMealTimes(*dryRun)
+	if report != nil {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	}
+	return err
+}
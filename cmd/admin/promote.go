@@ -0,0 +1,45 @@
+// cmd/admin/promote.go
+
+// 첫 관리자 지정 등 관리자 권한을 직접 부여할 때 사용하는 명령
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"log"
+
+	"github.com/seojoonrp/bapddang-server/api/repositories"
+	"github.com/seojoonrp/bapddang-server/api/services"
+	"github.com/seojoonrp/bapddang-server/models"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func runPromoteAdmin(db *mongo.Database, args []string) error {
+	flags := flag.NewFlagSet("promote-admin", flag.ExitOnError)
+	username := flags.String("username", "", "관리자로 지정할 유저의 username")
+	demote := flags.Bool("demote", false, "관리자 권한을 회수")
+	flags.Parse(args)
+
+	if *username == "" {
+		return errors.New("-username is required")
+	}
+
+	userRepository := repositories.NewUserRepository(db.Collection("users"))
+	// 역할 변경에는 음식/트랜잭션 관련 의존성이 필요 없음
+	userService := services.NewUserService(userRepository, nil, nil, nil)
+
+	if *demote {
+		if err := userService.DemoteFromAdmin(*username); err != nil {
+			return err
+		}
+		log.Printf("Set role of %s to %s", *username, models.RoleUser)
+		return nil
+	}
+
+	if err := userService.PromoteToAdmin(*username); err != nil {
+		return err
+	}
+	log.Printf("Set role of %s to %s", *username, models.RoleAdmin)
+	return nil
+}
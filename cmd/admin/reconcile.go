@@ -0,0 +1,36 @@
+// cmd/admin/reconcile.go
+
+// cron 등에서 주기적으로 실행하는 음식 통계 보정 명령
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+
+	"github.com/seojoonrp/bapddang-server/api/repositories"
+	"github.com/seojoonrp/bapddang-server/api/services"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func runReconcileFoodStats(db *mongo.Database, args []string) error {
+	flags := flag.NewFlagSet("reconcile-food-stats", flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "차이만 보고하고 보정하지 않음")
+	flags.Parse(args)
+
+	foodRepository := repositories.NewFoodRepository(db.Collection("standard_foods"), db.Collection("custom_foods"))
+	reviewRepository := repositories.NewReviewRepository(db.Collection("reviews"))
+	userRepository := repositories.NewUserRepository(db.Collection("users"))
+
+	statsService := services.NewStatsService(foodRepository, reviewRepository, userRepository)
+
+	report, err := statsService.ReconcileFoodStats(*dryRun)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
@@ -26,6 +26,11 @@ type Config struct {
 	AWSSecretAccessKey string
 	AWSS3BucketName    string
 	AWSRegion          string
+
+	StorageBackend         string
+	LocalStorageDir        string
+	LocalStorageSigningKey string
+	PublicBaseURL          string
 }
 
 var AppConfig *Config
@@ -51,6 +56,11 @@ func LoadConfig() {
 		AWSSecretAccessKey: getEnv("AWS_SECRET_ACCESS_KEY", ""),
 		AWSS3BucketName:    getEnv("AWS_S3_BUCKET_NAME", ""),
 		AWSRegion:          getEnv("AWS_REGION", ""),
+
+		StorageBackend:         getEnv("STORAGE_BACKEND", "s3"),
+		LocalStorageDir:        getEnv("LOCAL_STORAGE_DIR", "./uploads"),
+		LocalStorageSigningKey: getEnv("LOCAL_STORAGE_SIGNING_KEY", ""),
+		PublicBaseURL:          getEnv("PUBLIC_BASE_URL", ""),
 	}
 }
 
@@ -9,32 +9,216 @@ import (
 )
 
 type StandardFood struct {
-	ID       primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	Name     string             `bson:"name" json:"name" binding:"required"`
-	ImageURL string             `bson:"image_url" json:"imageURL" binding:"required"`
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name          string             `bson:"name" json:"name" binding:"required"`
+	ImageURL      string             `bson:"image_url" json:"imageURL" binding:"required"`
+	ImageKey      string             `bson:"image_key,omitempty" json:"-"`
+	ImageVariants *ImageVariants     `bson:"image_variants,omitempty" json:"imageVariants,omitempty"`
 
 	Speed      string   `bson:"speed" json:"speed" binding:"required"`
 	Type       string   `bson:"type" json:"type" binding:"required"`
 	Categories []string `bson:"categories" json:"categories"`
+	Aliases    []string `bson:"aliases" json:"aliases"`
+
+	// 외국인 사용자나 영문으로 입력하는 경우를 위한 영문 이름 ("Bibimbap")
+	// 비워 두어도 한글 이름의 로마자 표기로는 매칭됨
+	EnglishName string `bson:"english_name,omitempty" json:"englishName,omitempty"`
 
 	LikeCount   int `bson:"like_count" json:"likeCount"`
 	ReviewCount int `bson:"review_count" json:"reviewCount"`
 	TotalRating int `bson:"total_rating" json:"totalRating"`
+
+	Archived bool `bson:"archived" json:"archived"`
+}
+
+type ReviewStatsDiff struct {
+	FoodID      primitive.ObjectID
+	ReviewCount int
+	TotalRating int
+}
+
+type FoodStats struct {
+	FoodID      primitive.ObjectID `bson:"_id" json:"foodId"`
+	LikeCount   int                `bson:"like_count" json:"likeCount"`
+	ReviewCount int                `bson:"review_count" json:"reviewCount"`
+	TotalRating int                `bson:"total_rating" json:"totalRating"`
+}
+
+type FoodStatsDrift struct {
+	FoodID primitive.ObjectID `json:"foodId"`
+	Name   string             `json:"name"`
+	Stored FoodStats          `json:"stored"`
+	Actual FoodStats          `json:"actual"`
+}
+
+type FoodStatsReport struct {
+	CheckedCount int              `json:"checkedCount"`
+	DriftCount   int              `json:"driftCount"`
+	Drifts       []FoodStatsDrift `json:"drifts"`
+	Fixed        bool             `json:"fixed"`
+	StartedAt    time.Time        `json:"startedAt"`
+	FinishedAt   time.Time        `json:"finishedAt"`
 }
 
 type NewStandardFoodInput struct {
-	Name       string   `json:"name"`
-	ImageURL   string   `json:"imageURL"`
-	Speed      string   `json:"speed"`
-	Type       string   `json:"type"`
-	Categories []string `json:"categories"`
+	Name        string   `json:"name"`
+	ImageURL    string   `json:"imageURL"`
+	Speed       string   `json:"speed"`
+	Type        string   `json:"type"`
+	Categories  []string `json:"categories"`
+	Aliases     []string `json:"aliases"`
+	EnglishName string   `json:"englishName"`
+}
+
+type UpdateStandardFoodInput struct {
+	Name        *string   `json:"name"`
+	ImageURL    *string   `json:"imageURL"`
+	Speed       *string   `json:"speed"`
+	Type        *string   `json:"type"`
+	Categories  *[]string `json:"categories"`
+	Aliases     *[]string `json:"aliases"`
+	EnglishName *string   `json:"englishName"`
+}
+
+type StandardFoodAliasesInput struct {
+	Aliases []string `json:"aliases" binding:"required,min=1"`
+}
+
+type StandardFoodDeletionResult struct {
+	DeletedFood     *StandardFood `json:"deletedFood"`
+	ReplacementFood *CustomFood   `json:"replacementFood,omitempty"`
+	UpdatedReviews  int64         `json:"updatedReviews"`
+	RemovedLikes    int64         `json:"removedLikes"`
+}
+
+// 가져올 카탈로그 파일 내용
+type FoodCatalog struct {
+	Rows []FoodCatalogRow
+}
+
+// Columns: 이 행에 들어 있던 열 이름(CSV 헤더, JSON 키)을 소문자로 담음
+// 기존 음식을 수정할 때 파일에 없는 열의 값은 그대로 둠 (예전 형식으로 내보낸 파일을 가져와도 별칭 등이 지워지지 않도록)
+type FoodCatalogRow struct {
+	NewStandardFoodInput
+	Columns map[string]bool
+}
+
+type FoodImportChange struct {
+	Row    int                   `json:"row"`
+	FoodID primitive.ObjectID    `json:"foodId,omitempty"`
+	Before *NewStandardFoodInput `json:"before,omitempty"`
+	After  NewStandardFoodInput  `json:"after"`
+}
+
+type FoodImportRowError struct {
+	Row   int    `json:"row"`
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
+type FoodImportReport struct {
+	TotalRows      int                  `json:"totalRows"`
+	Created        []FoodImportChange   `json:"created"`
+	Updated        []FoodImportChange   `json:"updated"`
+	UnchangedCount int                  `json:"unchangedCount"`
+	Errors         []FoodImportRowError `json:"errors"`
+	DryRun         bool                 `json:"dryRun"`
+	Applied        bool                 `json:"applied"`
 }
 
 type CustomFood struct {
-	ID           primitive.ObjectID   `bson:"_id, omitempty" json:"id"`
-	Name         string               `bson:"name" json:"name" binding:"required"`
-	UsingUserIDs []primitive.ObjectID `bson:"using_user_ids" json:"usingUserIDs" binding:"required"`
-	CreatedAt    time.Time            `bson:"created_at" json:"createdAt"`
+	ID   primitive.ObjectID `bson:"_id, omitempty" json:"id"`
+	Name string             `bson:"name" json:"name" binding:"required"`
+	// 중복 생성을 막는 unique 색인용 (utils.NormalizeBasic)
+	NormalizedName string               `bson:"normalized_name" json:"-"`
+	UsingUserIDs   []primitive.ObjectID `bson:"using_user_ids" json:"usingUserIDs" binding:"required"`
+	CreatedAt      time.Time            `bson:"created_at" json:"createdAt"`
+}
+
+type CustomFoodRanking struct {
+	ID        primitive.ObjectID `bson:"_id" json:"id"`
+	Name      string             `bson:"name" json:"name"`
+	UserCount int                `bson:"user_count" json:"userCount"`
+	CreatedAt time.Time          `bson:"created_at" json:"createdAt"`
+}
+
+// 이름이 비슷한 custom 음식 묶음. 사용자 수가 가장 많은 음식이 대표가 됨
+type CustomFoodCluster struct {
+	Representative       CustomFoodRanking   `json:"representative"`
+	Members              []CustomFoodRanking `json:"members"`
+	TotalUserCount       int                 `json:"totalUserCount"`
+	SimilarStandardFoods []ValidationOutput  `json:"similarStandardFoods"`
+}
+
+type PromoteCustomFoodInput struct {
+	Name        string   `json:"name"`
+	ImageURL    string   `json:"imageURL"`
+	Speed       string   `json:"speed"`
+	Type        string   `json:"type"`
+	Categories  []string `json:"categories"`
+	EnglishName string   `json:"englishName"`
+}
+
+type CustomFoodPromotionResult struct {
+	StandardFood   *StandardFood `json:"standardFood"`
+	UpdatedReviews int64         `json:"updatedReviews"`
+}
+
+// 병합으로 사라진 음식 ID가 살아남은 음식을 가리키도록 남겨두는 기록
+type FoodRedirect struct {
+	FromID    primitive.ObjectID `bson:"_id" json:"fromId"`
+	ToID      primitive.ObjectID `bson:"to_id" json:"toId"`
+	ToType    string             `bson:"to_type" json:"toType"`
+	CreatedAt time.Time          `bson:"created_at" json:"createdAt"`
+}
+
+type MergeFoodsInput struct {
+	SurvivorID string   `json:"survivorId" binding:"required"`
+	VictimIDs  []string `json:"victimIds" binding:"required,min=1"`
+}
+
+// normalized_name이 같은 custom 음식들을 합친 결과 (사용자가 가장 많은 음식이 Survivor)
+type CustomFoodDedupeGroup struct {
+	NormalizedName string             `json:"normalizedName"`
+	Survivor       ValidationOutput   `json:"survivor"`
+	MergedFoods    []ValidationOutput `json:"mergedFoods"`
+	UpdatedReviews int64              `json:"updatedReviews"`
+}
+
+type CustomFoodDedupeReport struct {
+	DryRun bool                    `json:"dryRun"`
+	Groups []CustomFoodDedupeGroup `json:"groups"`
+}
+
+type MergeFoodsResult struct {
+	Survivor       ValidationOutput   `json:"survivor"`
+	MergedFoods    []ValidationOutput `json:"mergedFoods"`
+	UpdatedReviews int64              `json:"updatedReviews"`
+	UpdatedLikes   int64              `json:"updatedLikes"`
+}
+
+type FoodSearchQuery struct {
+	Query    string
+	Type     string
+	Speed    string
+	Category string
+	Limit    int
+	Offset   int
+}
+
+type FoodSearchResult struct {
+	ID          primitive.ObjectID `json:"id"`
+	Name        string             `json:"name"`
+	Type        string             `json:"type"`
+	MatchedName string             `json:"matchedName"`
+	Score       float64            `json:"score"`
+	Food        *StandardFood      `json:"food,omitempty"`
+}
+
+type FoodSearchPage struct {
+	Results    []FoodSearchResult `json:"results"`
+	Total      int                `json:"total"`
+	NextOffset *int               `json:"nextOffset"`
 }
 
 type NewCustomFoodInput struct {
@@ -58,3 +242,64 @@ type ValidationResult struct {
 	SuggestionOutputs []ValidationOutput `json:"suggestionOutputs,omitempty"`
 	NewOutput         *ValidationOutput  `json:"newOutput,omitempty"`
 }
+
+type FoodChangeStreamStatus struct {
+	Collection  string     `json:"collection"`
+	Active      bool       `json:"active"`
+	Resumable   bool       `json:"resumable"`
+	EventCount  int64      `json:"eventCount"`
+	Restarts    int        `json:"restarts"`
+	LastEventAt *time.Time `json:"lastEventAt"`
+	LastError   string     `json:"lastError,omitempty"`
+}
+
+type FoodCacheStatus struct {
+	Version           uint64                   `json:"version"`
+	StandardFoodCount int                      `json:"standardFoodCount"`
+	CustomFoodCount   int                      `json:"customFoodCount"`
+	LastFullSyncAt    time.Time                `json:"lastFullSyncAt"`
+	AgeSeconds        float64                  `json:"ageSeconds"`
+	LastChangeAt      *time.Time               `json:"lastChangeAt"`
+	ChangeStreams     []FoodChangeStreamStatus `json:"changeStreams"`
+
+	// custom 음식 이름 unique 색인 유무. false면 중복 custom 음식이 남아 있어 색인을 만들지 못한 상태
+	CustomFoodNameIndex      bool   `json:"customFoodNameIndex"`
+	CustomFoodNameIndexError string `json:"customFoodNameIndexError,omitempty"`
+}
+
+// 메인 피드 점수에 반영된 항목 하나 (왜 이 음식이 추천됐는지 보여주기 위함)
+type FeedReason struct {
+	Code   string  `json:"code"`
+	Score  float64 `json:"score"`
+	Detail string  `json:"detail,omitempty"`
+}
+
+// 로그인하지 않은 요청은 Score/Reasons 없이 음식 정보만 내려감
+type MainFeedFood struct {
+	*StandardFood
+	Score   float64      `json:"score,omitempty"`
+	Reasons []FeedReason `json:"reasons,omitempty"`
+}
+
+// Cursor가 있으면 Type/Speed/Seed/끼니는 cursor에 담긴 값을 따름
+// Seed와 Cursor가 모두 없으면 예전처럼 음식 배열만 응답함
+type MainFeedQuery struct {
+	Type   string
+	Speed  string
+	Count  int
+	Seed   *int64
+	Cursor string
+
+	// 끼니 추천 모드. MealTime이 비어 있으면 현재 한국 시간으로 끼니를 정함
+	MealTimeMode bool
+	MealTime     string
+}
+
+// 같은 Seed로 처음부터 다시 요청하면 (데이터가 바뀌지 않았다면) 같은 순서가 나옴
+// NextCursor가 비어 있으면 더 보여줄 음식이 없음
+type MainFeedPage struct {
+	Foods      []MainFeedFood `json:"foods"`
+	Seed       int64          `json:"seed,string"`
+	MealTime   string         `json:"mealTime,omitempty"`
+	NextCursor string         `json:"nextCursor,omitempty"`
+}
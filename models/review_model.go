@@ -22,10 +22,12 @@ type Review struct {
 	Speed    string             `bson:"speed" json:"speed"`
 	MealTime string             `bson:"meal_time" json:"mealTime"`
 
-	Tags     []string `bson:"tags" json:"tags"`
-	ImageURL string   `bson:"image_url" json:"imageUrl"`
-	Comment  string   `bson:"comment" json:"comment"`
-	Rating   int      `bson:"rating" json:"rating"`
+	Tags          []string       `bson:"tags" json:"tags"`
+	ImageURL      string         `bson:"image_url" json:"imageUrl"`
+	ImageKey      string         `bson:"image_key,omitempty" json:"imageKey,omitempty"`
+	ImageVariants *ImageVariants `bson:"image_variants,omitempty" json:"imageVariants,omitempty"`
+	Comment       string         `bson:"comment" json:"comment"`
+	Rating        int            `bson:"rating" json:"rating"`
 
 	Day       int       `bson:"day" json:"day"`
 	CreatedAt time.Time `bson:"created_at" json:"createdAt"`
@@ -38,7 +40,60 @@ type ReviewInput struct {
 	Speed    string             `json:"speed" binding:"required"`
 	MealTime string             `json:"mealTime" binding:"required"`
 	Tags     []string           `json:"tags"`
-	ImageURL string             `json:"imageUrl"`
+	ImageKey string             `json:"imageKey"`
 	Comment  string             `json:"comment"`
 	Rating   int                `json:"rating"`
+
+	// 더 이상 받지 않음. 예전 클라이언트가 보내면 이미지가 조용히 빠지지 않도록 400으로 거절함
+	ImageURL string `json:"imageUrl"`
+}
+
+type ReviewHistoryQuery struct {
+	UserID primitive.ObjectID
+
+	DayFrom     *int
+	DayTo       *int
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+
+	MealTime  string
+	Speed     string
+	Tags      []string
+	MinRating int
+	FoodID    *primitive.ObjectID
+
+	Cursor *primitive.ObjectID
+	Limit  int
+}
+
+type ReviewHistoryPage struct {
+	Reviews    []Review `json:"reviews"`
+	NextCursor string   `json:"nextCursor,omitempty"`
+}
+
+type MealTimeCount struct {
+	MealTime string `bson:"meal_time" json:"mealTime"`
+	Count    int64  `bson:"count" json:"count"`
+}
+
+// 예전 리뷰에 클라이언트가 보낸 그대로 저장된 meal_time을 끼니 값으로 바꾼 결과
+// Unknown은 어느 끼니인지 알 수 없어 그대로 둔 값
+type MealTimeBackfillReport struct {
+	Changes      []MealTimeBackfillChange `json:"changes"`
+	Unknown      []MealTimeCount          `json:"unknown"`
+	UpdatedCount int64                    `json:"updatedCount"`
+	DryRun       bool                     `json:"dryRun"`
+}
+
+type MealTimeBackfillChange struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Count int64  `json:"count"`
+}
+
+// 음식별로 어느 끼니(meal_time)에 몇 번 리뷰되었는지. MealTime은 리뷰에 저장된 값 그대로
+type FoodMealTimeCount struct {
+	FoodID   primitive.ObjectID `bson:"food_id"`
+	MealTime string             `bson:"meal_time"`
+	Count    int                `bson:"count"`
 }
@@ -0,0 +1,41 @@
+// models/upload_model.go
+
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	UploadStatusPending  = "pending"
+	UploadStatusAttached = "attached"
+)
+
+type Upload struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Key       string             `bson:"key" json:"key"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"userId"`
+	Status    string             `bson:"status" json:"status"`
+	Processed bool               `bson:"processed" json:"processed"`
+	CreatedAt time.Time          `bson:"created_at" json:"createdAt"`
+}
+
+type PresignedUpload struct {
+	Key         string    `json:"key"`
+	UploadURL   string    `json:"uploadURL"`
+	ContentType string    `json:"contentType"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
+
+type ImageVariants struct {
+	Thumbnail string `bson:"thumbnail" json:"thumbnail"`
+	Medium    string `bson:"medium" json:"medium"`
+}
+
+type StoredImage struct {
+	Key      string
+	URL      string
+	Variants ImageVariants
+}
@@ -15,6 +15,11 @@ const (
 	LoginMethodApple  = "apple"
 )
 
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 type User struct {
 	ID           primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
 	Username     string               `bson:"username" json:"username"`
@@ -22,6 +27,7 @@ type User struct {
 	Password     string               `bson:"password,omitempty" json:"-"`
 	Email        string               `bson:"email,omitempty" json:"email"`
 	LoginMethod  string               `bson:"login_method" json:"loginMethod"`
+	Role         string               `bson:"role,omitempty" json:"role"`
 	Day          int                  `bson:"day" json:"day"`
 	LikedFoodIDs []primitive.ObjectID `bson:"liked_food_ids" json:"likedFoodIDs"`
 	CreatedAt    time.Time            `bson:"created_at" json:"createdAt"`
@@ -0,0 +1,182 @@
+// storage/local.go
+
+// AWS 자격 증명이 없는 로컬 개발/CI 환경용 디스크 저장소
+// 파일은 서버의 /storage 경로를 통해 업로드/제공되며, 업로드 URL은 HMAC으로 서명함
+
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const LocalRoutePrefix = "/storage/"
+
+// 설정 예시나 JWT 기본값을 그대로 쓰면 누구나 업로드 URL을 위조할 수 있으므로 거부함
+var insecureSigningKeys = map[string]bool{
+	"":               true,
+	"default_secret": true,
+}
+
+var (
+	ErrWeakSigningKey = errors.New("LOCAL_STORAGE_SIGNING_KEY must be set to a dedicated secret")
+	ErrUploadExpired  = errors.New("upload URL expired")
+	ErrInvalidUpload  = errors.New("invalid upload signature")
+)
+
+type LocalStorage struct {
+	rootDir string
+	baseURL *url.URL
+	secret  []byte
+}
+
+func NewLocalStorage(rootDir, baseURL, secret string) (*LocalStorage, error) {
+	if insecureSigningKeys[secret] {
+		return nil, ErrWeakSigningKey
+	}
+	parsedBaseURL, err := parsePublicBaseURL(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(rootDir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &LocalStorage{
+		rootDir: rootDir,
+		baseURL: parsedBaseURL,
+		secret:  []byte(secret),
+	}, nil
+}
+
+func (s *LocalStorage) filePath(key string) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.rootDir, filepath.FromSlash(key)), nil
+}
+
+func (s *LocalStorage) Put(key string, body io.Reader, contentType string) error {
+	fullPath, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+		return err
+	}
+
+	// 쓰는 도중에 읽히지 않도록 임시 파일에 쓴 뒤 rename
+	tmpFile, err := os.CreateTemp(filepath.Dir(fullPath), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := io.Copy(tmpFile, body); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile.Name(), fullPath)
+}
+
+func (s *LocalStorage) Get(key string) (io.ReadCloser, error) {
+	fullPath, err := s.filePath(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(fullPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (s *LocalStorage) Delete(key string) error {
+	fullPath, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(fullPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalStorage) Exists(key string) (bool, error) {
+	fullPath, err := s.filePath(key)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = os.Stat(fullPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *LocalStorage) PresignPut(key, contentType string, expires time.Duration) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+
+	expiresAt := strconv.FormatInt(time.Now().Add(expires).Unix(), 10)
+
+	query := url.Values{}
+	query.Set("expires", expiresAt)
+	query.Set("signature", s.sign(key, contentType, expiresAt))
+
+	return s.PublicURL(key) + "?" + query.Encode(), nil
+}
+
+func (s *LocalStorage) PublicURL(key string) string {
+	return joinPublicURL(s.baseURL, strings.TrimPrefix(LocalRoutePrefix, "/")+key)
+}
+
+// PresignPut으로 발급한 URL의 서명과 만료 시각을 검증
+func (s *LocalStorage) VerifyPresignedPut(key, contentType, expiresAt, signature string) error {
+	expiresUnix, err := strconv.ParseInt(expiresAt, 10, 64)
+	if err != nil {
+		return ErrInvalidUpload
+	}
+	if time.Now().Unix() > expiresUnix {
+		return ErrUploadExpired
+	}
+
+	expected := s.sign(key, contentType, expiresAt)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidUpload
+	}
+
+	return nil
+}
+
+func (s *LocalStorage) sign(key, contentType, expiresAt string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(key + "\n" + contentType + "\n" + expiresAt))
+	return hex.EncodeToString(mac.Sum(nil))
+}
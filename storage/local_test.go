@@ -0,0 +1,162 @@
+// storage/local_test.go
+
+package storage
+
+import (
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSigningKey = "test-local-signing-key"
+
+func newTestLocalStorage(t *testing.T) *LocalStorage {
+	t.Helper()
+	s, err := NewLocalStorage(t.TempDir(), "http://localhost:8080/", testSigningKey)
+	if err != nil {
+		t.Fatalf("NewLocalStorage: %v", err)
+	}
+	return s
+}
+
+// 발급된 URL에서 key와 쿼리 파라미터를 꺼냄
+func parsePresignedURL(t *testing.T, rawURL string) (string, url.Values) {
+	t.Helper()
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatalf("presigned URL %q: %v", rawURL, err)
+	}
+	if !strings.HasPrefix(parsed.Path, LocalRoutePrefix) {
+		t.Fatalf("presigned URL path %q does not start with %q", parsed.Path, LocalRoutePrefix)
+	}
+	return strings.TrimPrefix(parsed.Path, LocalRoutePrefix), parsed.Query()
+}
+
+func TestNewLocalStorageRejectsWeakSigningKey(t *testing.T) {
+	for _, secret := range []string{"", "default_secret"} {
+		if _, err := NewLocalStorage(t.TempDir(), "http://localhost:8080", secret); !errors.Is(err, ErrWeakSigningKey) {
+			t.Errorf("NewLocalStorage(secret=%q) error = %v, want ErrWeakSigningKey", secret, err)
+		}
+	}
+}
+
+func TestLocalStoragePresignVerifyRoundTrip(t *testing.T) {
+	s := newTestLocalStorage(t)
+
+	rawURL, err := s.PresignPut("reviews/abc.jpg", "image/jpeg", time.Minute)
+	if err != nil {
+		t.Fatalf("PresignPut: %v", err)
+	}
+	if !strings.HasPrefix(rawURL, "http://localhost:8080/storage/reviews/abc.jpg?") {
+		t.Fatalf("PresignPut URL = %q", rawURL)
+	}
+
+	key, query := parsePresignedURL(t, rawURL)
+	if err := s.VerifyPresignedPut(key, "image/jpeg", query.Get("expires"), query.Get("signature")); err != nil {
+		t.Fatalf("VerifyPresignedPut on issued URL: %v", err)
+	}
+}
+
+func TestLocalStorageVerifyRejectsTampering(t *testing.T) {
+	s := newTestLocalStorage(t)
+
+	rawURL, err := s.PresignPut("reviews/abc.jpg", "image/jpeg", time.Minute)
+	if err != nil {
+		t.Fatalf("PresignPut: %v", err)
+	}
+	key, query := parsePresignedURL(t, rawURL)
+	expires, signature := query.Get("expires"), query.Get("signature")
+
+	other, err := NewLocalStorage(t.TempDir(), "http://localhost:8080", "another-signing-key")
+	if err != nil {
+		t.Fatalf("NewLocalStorage: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		storage     *LocalStorage
+		key         string
+		contentType string
+		expires     string
+		signature   string
+		want        error
+	}{
+		{"다른 key", s, "reviews/other.jpg", "image/jpeg", expires, signature, ErrInvalidUpload},
+		{"다른 Content-Type", s, key, "image/png", expires, signature, ErrInvalidUpload},
+		{"만료 시각 연장", s, key, "image/jpeg", expires + "0", signature, ErrInvalidUpload},
+		{"숫자가 아닌 만료 시각", s, key, "image/jpeg", "tomorrow", signature, ErrInvalidUpload},
+		{"빈 서명", s, key, "image/jpeg", expires, "", ErrInvalidUpload},
+		{"다른 서명 키", other, key, "image/jpeg", expires, signature, ErrInvalidUpload},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.storage.VerifyPresignedPut(tt.key, tt.contentType, tt.expires, tt.signature)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("VerifyPresignedPut error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalStorageVerifyRejectsExpiredURL(t *testing.T) {
+	s := newTestLocalStorage(t)
+
+	rawURL, err := s.PresignPut("reviews/abc.jpg", "image/jpeg", -time.Minute)
+	if err != nil {
+		t.Fatalf("PresignPut: %v", err)
+	}
+
+	key, query := parsePresignedURL(t, rawURL)
+	err = s.VerifyPresignedPut(key, "image/jpeg", query.Get("expires"), query.Get("signature"))
+	if !errors.Is(err, ErrUploadExpired) {
+		t.Errorf("VerifyPresignedPut error = %v, want ErrUploadExpired", err)
+	}
+}
+
+func TestLocalStoragePresignRejectsInvalidKey(t *testing.T) {
+	s := newTestLocalStorage(t)
+
+	for _, key := range []string{"", "/etc/passwd", "../secret.jpg", "reviews/../../secret.jpg"} {
+		if _, err := s.PresignPut(key, "image/jpeg", time.Minute); err == nil {
+			t.Errorf("PresignPut(%q) succeeded, want error", key)
+		}
+	}
+}
+
+func TestLocalStorageObjectLifecycle(t *testing.T) {
+	s := newTestLocalStorage(t)
+	const key = "reviews/abc.jpg"
+
+	if err := s.Put(key, strings.NewReader("image-bytes"), "image/jpeg"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	exists, err := s.Exists(key)
+	if err != nil || !exists {
+		t.Fatalf("Exists after Put = %v, %v; want true, nil", exists, err)
+	}
+
+	body, err := s.Get(key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	data, err := io.ReadAll(body)
+	body.Close()
+	if err != nil || string(data) != "image-bytes" {
+		t.Fatalf("Get body = %q, %v; want %q", data, err, "image-bytes")
+	}
+
+	if err := s.Delete(key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Get(key); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Get after Delete error = %v, want ErrObjectNotFound", err)
+	}
+	if err := s.Delete(key); err != nil {
+		t.Errorf("Delete of missing object: %v", err)
+	}
+}
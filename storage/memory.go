@@ -0,0 +1,98 @@
+// storage/memory.go
+
+// 테스트용 인메모리 저장소. 프로세스가 종료되면 내용이 사라짐
+
+package storage
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"time"
+)
+
+type memoryObject struct {
+	data        []byte
+	contentType string
+}
+
+type MemoryStorage struct {
+	objects map[string]memoryObject
+	lock    sync.RWMutex
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		objects: make(map[string]memoryObject),
+	}
+}
+
+func (s *MemoryStorage) Put(key string, body io.Reader, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	s.objects[key] = memoryObject{data: data, contentType: contentType}
+	s.lock.Unlock()
+
+	return nil
+}
+
+func (s *MemoryStorage) Get(key string) (io.ReadCloser, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	object, exists := s.objects[key]
+	if !exists {
+		return nil, ErrObjectNotFound
+	}
+
+	return io.NopCloser(bytes.NewReader(object.data)), nil
+}
+
+func (s *MemoryStorage) Delete(key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	delete(s.objects, key)
+	s.lock.Unlock()
+
+	return nil
+}
+
+func (s *MemoryStorage) Exists(key string) (bool, error) {
+	if err := validateKey(key); err != nil {
+		return false, err
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	_, exists := s.objects[key]
+	return exists, nil
+}
+
+// 실제 업로드 URL이 없으므로 key를 그대로 담은 가짜 URL을 반환
+// 테스트에서는 Put으로 직접 객체를 넣으면 됨
+func (s *MemoryStorage) PresignPut(key, contentType string, expires time.Duration) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	return "memory://" + key, nil
+}
+
+func (s *MemoryStorage) PublicURL(key string) string {
+	return "memory://" + key
+}
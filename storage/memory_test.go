@@ -0,0 +1,84 @@
+// storage/memory_test.go
+
+package storage
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMemoryStorageObjectLifecycle(t *testing.T) {
+	s := NewMemoryStorage()
+	const key = "reviews/abc.jpg"
+
+	if exists, err := s.Exists(key); err != nil || exists {
+		t.Fatalf("Exists before Put = %v, %v; want false, nil", exists, err)
+	}
+	if _, err := s.Get(key); !errors.Is(err, ErrObjectNotFound) {
+		t.Fatalf("Get before Put error = %v, want ErrObjectNotFound", err)
+	}
+
+	if err := s.Put(key, strings.NewReader("first"), "image/jpeg"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := s.Put(key, strings.NewReader("second"), "image/jpeg"); err != nil {
+		t.Fatalf("Put overwrite: %v", err)
+	}
+
+	body, err := s.Get(key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	data, err := io.ReadAll(body)
+	body.Close()
+	if err != nil || string(data) != "second" {
+		t.Fatalf("Get body = %q, %v; want %q", data, err, "second")
+	}
+
+	if err := s.Delete(key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if exists, err := s.Exists(key); err != nil || exists {
+		t.Errorf("Exists after Delete = %v, %v; want false, nil", exists, err)
+	}
+}
+
+func TestMemoryStorageRejectsInvalidKey(t *testing.T) {
+	s := NewMemoryStorage()
+
+	for _, key := range []string{"", "/abs.jpg", "../secret.jpg"} {
+		if err := s.Put(key, strings.NewReader("x"), "image/jpeg"); err == nil {
+			t.Errorf("Put(%q) succeeded, want error", key)
+		}
+		if _, err := s.PresignPut(key, "image/jpeg", time.Minute); err == nil {
+			t.Errorf("PresignPut(%q) succeeded, want error", key)
+		}
+		if _, err := s.Get(key); err == nil || errors.Is(err, ErrObjectNotFound) {
+			t.Errorf("Get(%q) error = %v, want invalid key error", key, err)
+		}
+		if _, err := s.Exists(key); err == nil {
+			t.Errorf("Exists(%q) succeeded, want error", key)
+		}
+		if err := s.Delete(key); err == nil {
+			t.Errorf("Delete(%q) succeeded, want error", key)
+		}
+	}
+}
+
+func TestMemoryStoragePresignAndPublicURL(t *testing.T) {
+	s := NewMemoryStorage()
+
+	presigned, err := s.PresignPut("reviews/abc.jpg", "image/jpeg", time.Minute)
+	if err != nil {
+		t.Fatalf("PresignPut: %v", err)
+	}
+	if presigned != "memory://reviews/abc.jpg" {
+		t.Errorf("PresignPut = %q, want %q", presigned, "memory://reviews/abc.jpg")
+	}
+	if got := s.PublicURL("reviews/abc.jpg"); got != presigned {
+		t.Errorf("PublicURL = %q, want %q", got, presigned)
+	}
+}
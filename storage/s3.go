@@ -0,0 +1,120 @@
+// storage/s3.go
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"time"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/seojoonrp/bapddang-server/config"
+)
+
+type S3Storage struct {
+	s3Client      *s3.Client
+	presignClient *s3.PresignClient
+	bucketName    string
+	publicBase    *url.URL
+}
+
+func NewS3Storage() (*S3Storage, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(context.TODO(), awsconfig.WithRegion(config.AppConfig.AWSRegion))
+	if err != nil {
+		return nil, err
+	}
+
+	publicBase, err := s3PublicBase(config.AppConfig.AWSS3BucketName, config.AppConfig.AWSRegion, config.AppConfig.PublicBaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	s3Client := s3.NewFromConfig(cfg)
+
+	return &S3Storage{
+		s3Client:      s3Client,
+		presignClient: s3.NewPresignClient(s3Client),
+		bucketName:    config.AppConfig.AWSS3BucketName,
+		publicBase:    publicBase,
+	}, nil
+}
+
+// PUBLIC_BASE_URL(CloudFront 등 CDN 주소)이 있으면 그 주소로, 없으면 버킷의 virtual-hosted 주소로 제공함
+func s3PublicBase(bucketName, region, baseURL string) (*url.URL, error) {
+	if baseURL != "" {
+		return parsePublicBaseURL(baseURL)
+	}
+	return &url.URL{Scheme: "https", Host: bucketName + ".s3." + region + ".amazonaws.com"}, nil
+}
+
+func (s *S3Storage) Put(key string, body io.Reader, contentType string) error {
+	_, err := s.s3Client.PutObject(context.TODO(), &s3.PutObjectInput{
+		Bucket:      &s.bucketName,
+		Key:         &key,
+		Body:        body,
+		ContentType: &contentType,
+	})
+	return err
+}
+
+func (s *S3Storage) Get(key string) (io.ReadCloser, error) {
+	output, err := s.s3Client.GetObject(context.TODO(), &s3.GetObjectInput{
+		Bucket: &s.bucketName,
+		Key:    &key,
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, err
+	}
+
+	return output.Body, nil
+}
+
+func (s *S3Storage) Delete(key string) error {
+	_, err := s.s3Client.DeleteObject(context.TODO(), &s3.DeleteObjectInput{
+		Bucket: &s.bucketName,
+		Key:    &key,
+	})
+	return err
+}
+
+func (s *S3Storage) Exists(key string) (bool, error) {
+	_, err := s.s3Client.HeadObject(context.TODO(), &s3.HeadObjectInput{
+		Bucket: &s.bucketName,
+		Key:    &key,
+	})
+	if err != nil {
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// 서명에 Content-Type이 포함되므로 클라이언트는 같은 헤더로 업로드해야 함
+func (s *S3Storage) PresignPut(key, contentType string, expires time.Duration) (string, error) {
+	req, err := s.presignClient.PresignPutObject(context.TODO(), &s3.PutObjectInput{
+		Bucket:      &s.bucketName,
+		Key:         &key,
+		ContentType: &contentType,
+	}, s3.WithPresignExpires(expires))
+	if err != nil {
+		return "", err
+	}
+
+	return req.URL, nil
+}
+
+func (s *S3Storage) PublicURL(key string) string {
+	return joinPublicURL(s.publicBase, key)
+}
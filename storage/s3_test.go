@@ -0,0 +1,40 @@
+// storage/s3_test.go
+
+package storage
+
+import "testing"
+
+func TestS3StoragePublicURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		key     string
+		want    string
+	}{
+		{"기본 버킷 주소", "", "reviews/abc.jpg", "https://bapddang.s3.ap-northeast-2.amazonaws.com/reviews/abc.jpg"},
+		{"key 조각마다 escape", "", "reviews/김치 찌개#1?.jpg", "https://bapddang.s3.ap-northeast-2.amazonaws.com/reviews/%EA%B9%80%EC%B9%98%20%EC%B0%8C%EA%B0%9C%231%3F.jpg"},
+		{"CDN 주소", "https://cdn.example.com", "reviews/abc.jpg", "https://cdn.example.com/reviews/abc.jpg"},
+		{"경로가 있는 CDN 주소", "https://cdn.example.com/images/", "reviews/abc.jpg", "https://cdn.example.com/images/reviews/abc.jpg"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publicBase, err := s3PublicBase("bapddang", "ap-northeast-2", tt.baseURL)
+			if err != nil {
+				t.Fatalf("s3PublicBase: %v", err)
+			}
+			s := &S3Storage{bucketName: "bapddang", publicBase: publicBase}
+			if got := s.PublicURL(tt.key); got != tt.want {
+				t.Errorf("PublicURL(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestS3PublicBaseRejectsInvalidURL(t *testing.T) {
+	for _, baseURL := range []string{"cdn.example.com", "/images", "https://"} {
+		if _, err := s3PublicBase("bapddang", "ap-northeast-2", baseURL); err == nil {
+			t.Errorf("s3PublicBase(%q) succeeded, want error", baseURL)
+		}
+	}
+}
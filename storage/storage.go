@@ -0,0 +1,91 @@
+// storage/storage.go
+
+// 이미지 등 파일 저장소 추상화
+// STORAGE_BACKEND 설정에 따라 S3, 로컬 디스크, 메모리 구현 중 하나를 사용함
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/seojoonrp/bapddang-server/config"
+)
+
+const (
+	BackendS3     = "s3"
+	BackendLocal  = "local"
+	BackendMemory = "memory"
+)
+
+// 로컬 저장소에서 PUBLIC_BASE_URL이 비어 있을 때 쓰는 서버 주소
+const defaultLocalBaseURL = "http://localhost:8080"
+
+var ErrObjectNotFound = errors.New("object not found")
+
+type ObjectStorage interface {
+	Put(key string, body io.Reader, contentType string) error
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+	Exists(key string) (bool, error)
+	PresignPut(key, contentType string, expires time.Duration) (string, error)
+	PublicURL(key string) string
+}
+
+func New() (ObjectStorage, error) {
+	switch config.AppConfig.StorageBackend {
+	case BackendS3:
+		return NewS3Storage()
+	case BackendLocal:
+		// 업로드 URL 서명 키가 새어도 JWT를 위조할 수 없도록 JWT 시크릿과 다른 키를 요구
+		if config.AppConfig.LocalStorageSigningKey == config.AppConfig.JWTSecret {
+			return nil, ErrWeakSigningKey
+		}
+		baseURL := config.AppConfig.PublicBaseURL
+		if baseURL == "" {
+			baseURL = defaultLocalBaseURL
+		}
+		return NewLocalStorage(config.AppConfig.LocalStorageDir, baseURL, config.AppConfig.LocalStorageSigningKey)
+	case BackendMemory:
+		return NewMemoryStorage(), nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend: %s", config.AppConfig.StorageBackend)
+	}
+}
+
+// key에 상위 경로 참조나 절대 경로가 섞여 들어오지 않도록 검사
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || strings.HasPrefix(key, "..") {
+		return errors.New("invalid object key")
+	}
+	return nil
+}
+
+// PUBLIC_BASE_URL 같은 공개 주소 설정을 검사함. scheme과 host가 있어야 함
+func parsePublicBaseURL(baseURL string) (*url.URL, error) {
+	parsed, err := url.Parse(strings.TrimSpace(baseURL))
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid public base URL: %q", baseURL)
+	}
+	return parsed, nil
+}
+
+// base 뒤에 key를 붙인 URL. key는 "/"로 나눈 조각마다 path escape함
+func joinPublicURL(base *url.URL, key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	joined := *base
+	joined.Path = strings.TrimSuffix(base.Path, "/") + "/" + key
+	joined.RawPath = strings.TrimSuffix(base.EscapedPath(), "/") + "/" + strings.Join(segments, "/")
+	joined.RawQuery = ""
+	joined.Fragment = ""
+	return joined.String()
+}
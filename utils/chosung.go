@@ -0,0 +1,94 @@
+// utils/chosung.go
+
+package utils
+
+// 초성 검색
+// "ㄸㅂㅇ"처럼 초성만 치거나 "김ㅊㅉㄱ"처럼 일부만 초성으로 친 검색어를 음식 이름과 글자 단위로 맞춰봄
+
+const (
+	consonantJamoStart = 0x3131 // ㄱ
+	consonantJamoEnd   = 0x314E // ㅎ
+
+	chosungPrefixBase   = 0.9
+	chosungContainsBase = 0.8
+)
+
+// IsConsonantJamo: 자음 자모 하나인지 여부 (호환용 자모 ㄱ~ㅎ)
+func IsConsonantJamo(ch rune) bool {
+	return ch >= consonantJamoStart && ch <= consonantJamoEnd
+}
+
+// HasLoneConsonant: 음절로 조합되지 않은 자음이 섞여 있는지 여부
+func HasLoneConsonant(s string) bool {
+	for _, ch := range s {
+		if IsConsonantJamo(ch) {
+			return true
+		}
+	}
+	return false
+}
+
+func chosungOf(ch rune) rune {
+	if ch >= SBase && ch < SBase+SCount {
+		return LTable[int(ch-SBase)/NCount]
+	}
+	return ch
+}
+
+// ExtractChosung: 한글 음절을 초성으로 바꿈 ("떡볶이" -> "ㄸㅂㅇ"), 나머지 문자는 그대로 둠
+func ExtractChosung(s string) string {
+	var out []rune
+	for _, ch := range NormalizeBasic(s) {
+		out = append(out, chosungOf(ch))
+	}
+	return string(out)
+}
+
+// 검색어의 자음은 대상 글자의 초성과, 나머지 글자는 대상 글자 자체와 같아야 일치
+func chosungMatchesAt(query, target []rune, start int) bool {
+	for i, ch := range query {
+		if IsConsonantJamo(ch) {
+			if chosungOf(target[start+i]) != ch {
+				return false
+			}
+		} else if target[start+i] != ch {
+			return false
+		}
+	}
+	return true
+}
+
+// ChosungScore: 초성이 섞인 검색어의 점수 (0이면 매칭 안 됨)
+// 앞부분 일치는 0.9~1.0, 중간 일치는 0.8~0.9이며 검색어가 이름을 많이 덮을수록 높음
+func ChosungScore(query, target string) float64 {
+	if !HasLoneConsonant(query) {
+		return 0
+	}
+
+	q := []rune(NormalizeBasic(query))
+	t := []rune(NormalizeBasic(target))
+	if len(q) == 0 || len(q) > len(t) {
+		return 0
+	}
+
+	coverage := float64(len(q)) / float64(len(t))
+	for start := 0; start+len(q) <= len(t); start++ {
+		if !chosungMatchesAt(q, t, start) {
+			continue
+		}
+		if start == 0 {
+			return chosungPrefixBase + (1-chosungPrefixBase)*coverage
+		}
+		return chosungContainsBase + (chosungPrefixBase-chosungContainsBase)*coverage
+	}
+	return 0
+}
+
+// MatchScore: 음식 검증에 쓰는 유사도. 초성이 섞인 입력은 초성 매칭 점수도 함께 고려함
+func MatchScore(query, target string) float64 {
+	score := Score(query, target)
+	if chosungScore := ChosungScore(query, target); chosungScore > score {
+		return chosungScore
+	}
+	return score
+}
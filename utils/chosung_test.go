@@ -0,0 +1,63 @@
+// utils/chosung_test.go
+
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExtractChosung(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"떡볶이", "ㄸㅂㅇ"},
+		{"김치 찌개", "ㄱㅊㅉㄱ"},
+		{"ㄸ볶이", "ㄸㅂㅇ"},
+		{"BBQ치킨", "bbqㅊㅋ"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := ExtractChosung(tt.input); got != tt.want {
+			t.Errorf("ExtractChosung(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestChosungScore(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		target string
+		want   float64
+	}{
+		{"초성 전체 일치", "ㄸㅂㅇ", "떡볶이", 1.0},
+		{"초성 앞부분 일치", "ㄸㅂ", "떡볶이", chosungPrefixBase + (1-chosungPrefixBase)*2.0/3.0},
+		{"초성 중간 일치", "ㅂㅇ", "떡볶이", chosungContainsBase + (chosungPrefixBase-chosungContainsBase)*2.0/3.0},
+		{"음절과 초성 섞임", "김ㅊㅉㄱ", "김치찌개", 1.0},
+		{"띄어쓰기 무시", "ㄱㅊ ㅉㄱ", "김치 찌개", 1.0},
+		{"섞인 음절이 다르면 불일치", "된ㅈㅉㄱ", "김치찌개", 0},
+		{"초성 불일치", "ㄱㅊ", "떡볶이", 0},
+		{"초성이 없는 검색어", "떡볶이", "떡볶이", 0},
+		{"검색어가 이름보다 김", "ㄸㅂㅇㅇ", "떡볶이", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ChosungScore(tt.query, tt.target); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("ChosungScore(%q, %q) = %v, want %v", tt.query, tt.target, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchScoreUsesChosung(t *testing.T) {
+	if got := MatchScore("ㄸㅂㅇ", "떡볶이"); got != 1.0 {
+		t.Errorf("MatchScore(ㄸㅂㅇ, 떡볶이) = %v, want 1.0", got)
+	}
+	if got, want := MatchScore("떡복이", "떡볶이"), Score("떡복이", "떡볶이"); got != want {
+		t.Errorf("MatchScore(떡복이, 떡볶이) = %v, want Score %v for a query without lone consonants", got, want)
+	}
+}
@@ -0,0 +1,74 @@
+// utils/food_search.go
+
+package utils
+
+import (
+	"strings"
+)
+
+// 검색 점수 구간
+// 완전 일치 > 앞부분 일치(자동완성) > 중간 일치 > 오타 허용 유사도 순으로 항상 정렬되도록 구간을 나눔
+const (
+	SearchExactScore     = 1.0
+	searchPrefixBase     = 0.9
+	searchContainsBase   = 0.8
+	searchFuzzyWeight    = 0.8
+	searchChosungWeight  = 0.95
+	SearchFuzzyThreshold = 0.75
+)
+
+// 겹모음과 겹받침은 실제로 두 번 눌러 입력하므로 글자를 치는 도중에는 앞쪽 자모만 보일 수 있음
+var typingJamoSplit = map[rune][]rune{
+	'ㅘ': {'ㅗ', 'ㅏ'}, 'ㅙ': {'ㅗ', 'ㅐ'}, 'ㅚ': {'ㅗ', 'ㅣ'},
+	'ㅝ': {'ㅜ', 'ㅓ'}, 'ㅞ': {'ㅜ', 'ㅔ'}, 'ㅟ': {'ㅜ', 'ㅣ'},
+	'ㅢ': {'ㅡ', 'ㅣ'},
+	'ㄳ': {'ㄱ', 'ㅅ'}, 'ㄵ': {'ㄴ', 'ㅈ'}, 'ㄶ': {'ㄴ', 'ㅎ'},
+	'ㄺ': {'ㄹ', 'ㄱ'}, 'ㄻ': {'ㄹ', 'ㅁ'}, 'ㄼ': {'ㄹ', 'ㅂ'},
+	'ㄽ': {'ㄹ', 'ㅅ'}, 'ㄾ': {'ㄹ', 'ㅌ'}, 'ㄿ': {'ㄹ', 'ㅍ'},
+	'ㅀ': {'ㄹ', 'ㅎ'}, 'ㅄ': {'ㅂ', 'ㅅ'},
+}
+
+// ToTypingJamo: 자판에서 누르는 순서대로 자모를 분리
+// "김ㅊ"처럼 입력 중인 검색어가 "김치찌개"의 앞부분으로 인식되도록 하는 데 사용
+func ToTypingJamo(str string) string {
+	var out []rune
+	for _, ch := range ToJamoString(str) {
+		if split, exists := typingJamoSplit[ch]; exists {
+			out = append(out, split...)
+			continue
+		}
+		out = append(out, ch)
+	}
+	return string(out)
+}
+
+// SearchScore: 검색어와 음식 이름의 검색 점수 (0이면 매칭 안 됨)
+func SearchScore(query, target string) float64 {
+	q := ToTypingJamo(query)
+	t := ToTypingJamo(target)
+	if q == "" || t == "" {
+		return 0
+	}
+
+	if NormalizeBasic(query) == NormalizeBasic(target) {
+		return SearchExactScore
+	}
+
+	coverage := float64(len([]rune(q))) / float64(len([]rune(t)))
+	if strings.HasPrefix(t, q) {
+		return searchPrefixBase + (SearchExactScore-searchPrefixBase)*coverage
+	}
+	if strings.Contains(t, q) {
+		return searchContainsBase + (searchPrefixBase-searchContainsBase)*coverage
+	}
+
+	// 초성 매칭은 완전 일치보다는 항상 낮게 둠
+	if score := ChosungScore(query, target); score > 0 {
+		return score * searchChosungWeight
+	}
+
+	if score := Score(query, target); score >= SearchFuzzyThreshold {
+		return score * searchFuzzyWeight
+	}
+	return 0
+}
@@ -0,0 +1,196 @@
+// utils/food_search_index.go
+
+package utils
+
+import (
+	"math"
+	"sync"
+)
+
+// 자모 n-gram 역색인
+// 모든 음식에 Score를 돌리는 대신, 검색어와 n-gram을 일정 비율 이상 공유하는 음식만 후보로 추려냄
+// 후보에 대해서는 기존처럼 Score(Jaro-Winkler)로 최종 점수를 매김
+// 초성이 섞인 검색어는 자모 n-gram이 거의 겹치지 않으므로 초성 n-gram을 따로 색인해 사용함
+
+const (
+	ngramSize = 2
+
+	// 검색어와 음식 중 n-gram이 적은 쪽의 이 비율 이상을 공유해야 후보가 됨
+	// 합성 카탈로그 기준으로 Score 0.75 이상인 쌍의 약 98.5%가 이 조건을 만족함 (TestNgramIndexRecall)
+	// 비교 벤치마크: go test ./utils -bench 'Scan|NgramIndex'
+	DefaultMinGramOverlap = 0.3
+
+	gramStart = '^'
+	gramEnd   = '$'
+)
+
+type NgramIndex[K comparable, V any] struct {
+	lock            sync.RWMutex
+	minOverlap      float64
+	postings        map[string]map[K]struct{}
+	chosungPostings map[string]map[K]struct{}
+	entries         map[K]*ngramEntry[V]
+}
+
+type ngramEntry[V any] struct {
+	value        V
+	grams        []string
+	chosungGrams []string
+}
+
+func NewNgramIndex[K comparable, V any](minOverlap float64) *NgramIndex[K, V] {
+	return &NgramIndex[K, V]{
+		minOverlap:      minOverlap,
+		postings:        make(map[string]map[K]struct{}),
+		chosungPostings: make(map[string]map[K]struct{}),
+		entries:         make(map[K]*ngramEntry[V]),
+	}
+}
+
+// JamoNgrams: 자모 분리한 문자열 앞뒤에 경계 표시를 붙여 n-gram으로 자름
+// 경계 표시 덕분에 한 글자짜리 입력도 n-gram이 생기고, 앞부분이 같은 이름이 더 많이 겹침
+func JamoNgrams(s string) []string {
+	return paddedNgrams([]rune(ToJamoString(s)))
+}
+
+// ChosungNgrams: 초성만 뽑은 문자열의 n-gram ("떡볶이" -> "ㄸㅂㅇ")
+func ChosungNgrams(s string) []string {
+	return paddedNgrams([]rune(ExtractChosung(s)))
+}
+
+func paddedNgrams(runes []rune) []string {
+	if len(runes) == 0 {
+		return nil
+	}
+
+	padded := make([]rune, 0, len(runes)+2)
+	padded = append(padded, gramStart)
+	padded = append(padded, runes...)
+	padded = append(padded, gramEnd)
+
+	seen := make(map[string]bool)
+	grams := make([]string, 0, len(padded))
+	for i := 0; i+ngramSize <= len(padded); i++ {
+		gram := string(padded[i : i+ngramSize])
+		if !seen[gram] {
+			seen[gram] = true
+			grams = append(grams, gram)
+		}
+	}
+	return grams
+}
+
+// Set: key의 색인을 새 값과 텍스트로 교체함 (이름과 별칭처럼 여러 텍스트를 함께 색인할 수 있음)
+func (idx *NgramIndex[K, V]) Set(key K, value V, texts ...string) {
+	grams := collectNgrams(texts, JamoNgrams)
+	chosungGrams := collectNgrams(texts, ChosungNgrams)
+
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	idx.removeLocked(key)
+	idx.entries[key] = &ngramEntry[V]{value: value, grams: grams, chosungGrams: chosungGrams}
+	addPostings(idx.postings, key, grams)
+	addPostings(idx.chosungPostings, key, chosungGrams)
+}
+
+func collectNgrams(texts []string, ngrams func(string) []string) []string {
+	seen := make(map[string]bool)
+	grams := make([]string, 0)
+	for _, text := range texts {
+		for _, gram := range ngrams(text) {
+			if !seen[gram] {
+				seen[gram] = true
+				grams = append(grams, gram)
+			}
+		}
+	}
+	return grams
+}
+
+func addPostings[K comparable](postings map[string]map[K]struct{}, key K, grams []string) {
+	for _, gram := range grams {
+		posting, exists := postings[gram]
+		if !exists {
+			posting = make(map[K]struct{})
+			postings[gram] = posting
+		}
+		posting[key] = struct{}{}
+	}
+}
+
+func removePostings[K comparable](postings map[string]map[K]struct{}, key K, grams []string) {
+	for _, gram := range grams {
+		posting := postings[gram]
+		delete(posting, key)
+		if len(posting) == 0 {
+			delete(postings, gram)
+		}
+	}
+}
+
+func (idx *NgramIndex[K, V]) Remove(key K) {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	idx.removeLocked(key)
+}
+
+func (idx *NgramIndex[K, V]) removeLocked(key K) {
+	entry, exists := idx.entries[key]
+	if !exists {
+		return
+	}
+	removePostings(idx.postings, key, entry.grams)
+	removePostings(idx.chosungPostings, key, entry.chosungGrams)
+	delete(idx.entries, key)
+}
+
+func (idx *NgramIndex[K, V]) Len() int {
+	idx.lock.RLock()
+	defer idx.lock.RUnlock()
+
+	return len(idx.entries)
+}
+
+// Candidates: 검색어와 n-gram을 충분히 공유하는 값들을 돌려줌 (순서는 보장하지 않음)
+func (idx *NgramIndex[K, V]) Candidates(query string) []V {
+	chosung := HasLoneConsonant(query)
+
+	grams := JamoNgrams(query)
+	if chosung {
+		grams = ChosungNgrams(query)
+	}
+	if len(grams) == 0 {
+		return nil
+	}
+
+	idx.lock.RLock()
+	defer idx.lock.RUnlock()
+
+	postings := idx.postings
+	if chosung {
+		postings = idx.chosungPostings
+	}
+
+	shared := make(map[K]int)
+	for _, gram := range grams {
+		for key := range postings[gram] {
+			shared[key]++
+		}
+	}
+
+	candidates := make([]V, 0)
+	for key, count := range shared {
+		entry := idx.entries[key]
+		entryGrams := len(entry.grams)
+		if chosung {
+			entryGrams = len(entry.chosungGrams)
+		}
+		minShared := max(1, int(math.Ceil(float64(min(len(grams), entryGrams))*idx.minOverlap)))
+		if count >= minShared {
+			candidates = append(candidates, entry.value)
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,227 @@
+// utils/food_search_index_test.go
+
+package utils
+
+import (
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+const testSimilarityThreshold = 0.75
+
+var testFoodNames = []string{
+	"김치찌개", "된장찌개", "순두부찌개", "부대찌개", "김치볶음밥", "비빔밥", "돌솥비빔밥", "제육볶음",
+	"떡볶이", "라볶이", "순대국", "돼지국밥", "뼈해장국", "갈비탕", "삼계탕", "냉면", "물냉면", "비빔냉면",
+	"칼국수", "잔치국수", "짜장면", "짬뽕", "탕수육", "불고기", "닭갈비", "찜닭", "김밥", "참치김밥",
+	"돈까스", "치즈돈까스", "마라탕", "쌀국수", "초밥", "우동", "라멘", "카레", "샌드위치", "햄버거",
+	"피자", "파스타", "떡국", "만두", "군만두", "호떡", "붕어빵", "빙수", "팥빙수", "케이크", "마카롱", "와플",
+}
+
+// 실제 음식 이름 조각과 무작위 음절을 섞어 음식 이름처럼 보이는 문자열을 만듦
+func syntheticFoodName(r *rand.Rand, names []string) string {
+	name := make([]rune, 0)
+	for range 1 + r.Intn(2) {
+		if r.Intn(3) != 0 {
+			source := []rune(names[r.Intn(len(names))])
+			start := r.Intn(len(source))
+			end := min(len(source), start+1+r.Intn(3))
+			name = append(name, source[start:end]...)
+			continue
+		}
+		for range 1 + r.Intn(2) {
+			name = append(name, rune(SBase+r.Intn(SCount)))
+		}
+	}
+	return string(name)
+}
+
+// 글자 하나를 바꾸거나 빼거나 더해서 흔한 오타를 흉내 냄
+func misspell(r *rand.Rand, name string) string {
+	runes := []rune(name)
+	if len(runes) == 0 {
+		return name
+	}
+
+	i := r.Intn(len(runes))
+	switch r.Intn(3) {
+	case 0:
+		if runes[i] >= SBase && runes[i] < SBase+SCount {
+			// 받침만 바꿈
+			base := runes[i] - rune((runes[i]-SBase)%TCount)
+			runes[i] = base + rune(r.Intn(TCount))
+		}
+	case 1:
+		if len(runes) > 1 {
+			runes = append(runes[:i], runes[i+1:]...)
+		}
+	case 2:
+		runes = append(runes, rune(SBase+r.Intn(SCount)))
+	}
+	return string(runes)
+}
+
+// 실제 음식 이름에 합성 이름을 더한 카탈로그와, 절반은 오타 / 절반은 무작위인 검색어
+func syntheticSearchCorpus(size, queryCount int) ([]string, []string) {
+	r := rand.New(rand.NewSource(1))
+
+	names := slices.Clone(testFoodNames)
+	for len(names) < size {
+		names = append(names, syntheticFoodName(r, testFoodNames))
+	}
+
+	queries := make([]string, queryCount)
+	for i := range queries {
+		if i%2 == 0 {
+			queries[i] = misspell(r, names[r.Intn(len(names))])
+		} else {
+			queries[i] = syntheticFoodName(r, testFoodNames)
+		}
+	}
+	return names, queries
+}
+
+func newTestIndex(names []string) *NgramIndex[int, string] {
+	index := NewNgramIndex[int, string](DefaultMinGramOverlap)
+	for i, name := range names {
+		index.Set(i, name, name)
+	}
+	return index
+}
+
+func sortedCandidates(index *NgramIndex[int, string], query string) []string {
+	candidates := index.Candidates(query)
+	sort.Strings(candidates)
+	return candidates
+}
+
+func TestNgramIndexSetRemove(t *testing.T) {
+	index := NewNgramIndex[int, string](DefaultMinGramOverlap)
+
+	index.Set(1, "김치찌개", "김치찌개")
+	index.Set(2, "된장찌개", "된장찌개")
+	if index.Len() != 2 {
+		t.Fatalf("Len = %d, want 2", index.Len())
+	}
+	if got := sortedCandidates(index, "김치찌게"); !slices.Contains(got, "김치찌개") {
+		t.Errorf("Candidates(김치찌게) = %v, want it to contain 김치찌개", got)
+	}
+
+	index.Remove(1)
+	if index.Len() != 1 {
+		t.Fatalf("Len after Remove = %d, want 1", index.Len())
+	}
+	if got := sortedCandidates(index, "김치찌개"); slices.Contains(got, "김치찌개") {
+		t.Errorf("Candidates after Remove = %v, removed entry is still returned", got)
+	}
+	// 지운 음식의 n-gram만 가진 posting은 남지 않아야 함
+	for _, gram := range JamoNgrams("김치") {
+		if _, exists := index.postings[gram]; exists && !slices.Contains(JamoNgrams("된장찌개"), gram) {
+			t.Errorf("posting %q was left after Remove", gram)
+		}
+	}
+
+	// 없는 key를 지워도 아무 일 없음
+	index.Remove(42)
+	if index.Len() != 1 {
+		t.Fatalf("Len after removing a missing key = %d, want 1", index.Len())
+	}
+}
+
+func TestNgramIndexResetReplacesTexts(t *testing.T) {
+	index := NewNgramIndex[int, string](DefaultMinGramOverlap)
+
+	index.Set(1, "떡볶이", "떡볶이")
+	index.Set(1, "라멘", "라멘", "ramen")
+	if index.Len() != 1 {
+		t.Fatalf("Len = %d, want 1", index.Len())
+	}
+	if got := index.Candidates("떡볶이"); len(got) != 0 {
+		t.Errorf("Candidates(떡볶이) = %v, old text is still indexed", got)
+	}
+	if got := index.Candidates("라멘"); !slices.Equal(got, []string{"라멘"}) {
+		t.Errorf("Candidates(라멘) = %v, want [라멘]", got)
+	}
+	// 별칭처럼 함께 색인한 텍스트로도 찾을 수 있음
+	if got := index.Candidates("ramen"); !slices.Equal(got, []string{"라멘"}) {
+		t.Errorf("Candidates(ramen) = %v, want [라멘]", got)
+	}
+}
+
+func TestNgramIndexChosungCandidates(t *testing.T) {
+	index := newTestIndex(testFoodNames)
+
+	if got := sortedCandidates(index, "ㄸㅂㅇ"); !slices.Contains(got, "떡볶이") {
+		t.Errorf("Candidates(ㄸㅂㅇ) = %v, want it to contain 떡볶이", got)
+	}
+}
+
+// DefaultMinGramOverlap의 근거: 전체 스캔에서 Score가 기준 이상인 쌍을 색인 후보가 얼마나 포함하는지
+func TestNgramIndexRecall(t *testing.T) {
+	names, queries := syntheticSearchCorpus(3000, 300)
+	index := newTestIndex(names)
+
+	scanMatches, indexedMatches, candidates := 0, 0, 0
+	for _, query := range queries {
+		candidateSet := make(map[string]bool)
+		for _, name := range index.Candidates(query) {
+			candidateSet[name] = true
+		}
+		candidates += len(candidateSet)
+
+		for _, name := range names {
+			if Score(query, name) < testSimilarityThreshold {
+				continue
+			}
+			scanMatches++
+			if candidateSet[name] {
+				indexedMatches++
+			}
+		}
+	}
+
+	recall := float64(indexedMatches) / float64(scanMatches)
+	t.Logf("recall %.4f (%d/%d), avg candidates %.1f of %d names",
+		recall, indexedMatches, scanMatches, float64(candidates)/float64(len(queries)), len(names))
+	if recall < 0.98 {
+		t.Errorf("recall = %.4f, want at least 0.98", recall)
+	}
+	if candidates >= len(names)*len(queries)/2 {
+		t.Errorf("avg candidates %d of %d names, index does not narrow the search", candidates/len(queries), len(names))
+	}
+}
+
+func BenchmarkScan(b *testing.B) {
+	names, queries := syntheticSearchCorpus(5000, 200)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		query := queries[i%len(queries)]
+		for _, name := range names {
+			_ = Score(query, name) >= testSimilarityThreshold
+		}
+	}
+}
+
+func BenchmarkNgramIndex(b *testing.B) {
+	names, queries := syntheticSearchCorpus(5000, 200)
+	index := newTestIndex(names)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		query := queries[i%len(queries)]
+		for _, name := range index.Candidates(query) {
+			_ = Score(query, name) >= testSimilarityThreshold
+		}
+	}
+}
+
+func BenchmarkNgramIndexBuild(b *testing.B) {
+	names, _ := syntheticSearchCorpus(5000, 0)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		newTestIndex(names)
+	}
+}
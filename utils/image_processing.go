@@ -0,0 +1,249 @@
+// utils/image_processing.go
+
+// 업로드된 이미지 검증 및 재인코딩
+// 재인코딩 과정에서 EXIF(GPS 포함) 메타데이터는 모두 제거되므로, 방향 정보만 미리 읽어 픽셀에 반영함
+
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"net/http"
+
+	_ "image/png"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	MaxImageUploadSize = 10 << 20
+	maxImagePixels     = 40_000_000
+)
+
+type ImageVariantSpec struct {
+	Name    string
+	MaxEdge int
+	Quality int
+}
+
+var (
+	OriginalImageSpec  = ImageVariantSpec{Name: "original", MaxEdge: 2048, Quality: 85}
+	MediumImageSpec    = ImageVariantSpec{Name: "medium", MaxEdge: 1080, Quality: 82}
+	ThumbnailImageSpec = ImageVariantSpec{Name: "thumb", MaxEdge: 320, Quality: 80}
+)
+
+// 이미지 자체가 잘못된 경우 (클라이언트 잘못이므로 400으로 응답)
+var (
+	ErrImageTooLarge        = errors.New("image too large")
+	ErrUnsupportedImageType = errors.New("unsupported image type")
+	ErrInvalidImage         = errors.New("invalid image")
+)
+
+// IsImageValidationError: 저장소 오류가 아니라 이미지 내용 때문에 처리하지 못한 경우인지 여부
+func IsImageValidationError(err error) bool {
+	return errors.Is(err, ErrImageTooLarge) || errors.Is(err, ErrUnsupportedImageType) || errors.Is(err, ErrInvalidImage)
+}
+
+var allowedImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+}
+
+type ProcessedImage struct {
+	Original  []byte
+	Medium    []byte
+	Thumbnail []byte
+}
+
+// ProcessImage: 크기 제한 및 실제 내용 기반 타입 검사 후, 메타데이터를 제거한 JPEG 세 가지 크기로 재인코딩
+func ProcessImage(r io.Reader) (*ProcessedImage, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxImageUploadSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxImageUploadSize {
+		return nil, ErrImageTooLarge
+	}
+
+	if !allowedImageTypes[http.DetectContentType(data)] {
+		return nil, ErrUnsupportedImageType
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	if cfg.Width*cfg.Height > maxImagePixels {
+		return nil, ErrImageTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	orientation := readJPEGOrientation(data)
+
+	processed := &ProcessedImage{}
+	targets := []struct {
+		spec ImageVariantSpec
+		out  *[]byte
+	}{
+		{OriginalImageSpec, &processed.Original},
+		{MediumImageSpec, &processed.Medium},
+		{ThumbnailImageSpec, &processed.Thumbnail},
+	}
+
+	// 큰 크기부터 차례로 줄여 나가며 이전 결과를 다음 축소의 입력으로 사용
+	var current image.Image = src
+	for _, target := range targets {
+		resized := resizeToFit(current, target.spec.MaxEdge)
+		current = resized
+
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, applyOrientation(resized, orientation), &jpeg.Options{Quality: target.spec.Quality}); err != nil {
+			return nil, err
+		}
+		*target.out = buf.Bytes()
+	}
+
+	return processed, nil
+}
+
+// 긴 변이 maxEdge를 넘지 않도록 축소. 투명 영역은 흰 배경으로 채움
+func resizeToFit(src image.Image, maxEdge int) *image.RGBA {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width > maxEdge || height > maxEdge {
+		if width >= height {
+			height = max(1, height*maxEdge/width)
+			width = maxEdge
+		} else {
+			width = max(1, width*maxEdge/height)
+			height = maxEdge
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+
+	return dst
+}
+
+// EXIF Orientation(1~8) 값에 맞게 회전/반전
+func applyOrientation(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	width, height := src.Bounds().Dx(), src.Bounds().Dy()
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		for x := 0; x < dstWidth; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = width-1-x, y
+			case 3:
+				sx, sy = width-1-x, height-1-y
+			case 4:
+				sx, sy = x, height-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, height-1-x
+			case 7:
+				sx, sy = width-1-y, height-1-x
+			case 8:
+				sx, sy = width-1-y, x
+			}
+			dst.SetRGBA(x, y, src.RGBAAt(sx, sy))
+		}
+	}
+
+	return dst
+}
+
+// JPEG의 APP1(Exif) 세그먼트에서 Orientation 태그만 읽음. 없거나 읽을 수 없으면 1
+func readJPEGOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return 1
+		}
+		marker := data[pos+1]
+		if marker == 0xDA || marker == 0xD9 {
+			return 1
+		}
+
+		segmentLength := int(binary.BigEndian.Uint16(data[pos+2 : pos+4]))
+		segmentStart := pos + 4
+		segmentEnd := pos + 2 + segmentLength
+		if segmentLength < 2 || segmentEnd > len(data) {
+			return 1
+		}
+
+		if marker == 0xE1 && bytes.HasPrefix(data[segmentStart:segmentEnd], []byte("Exif\x00\x00")) {
+			return parseTIFFOrientation(data[segmentStart+6 : segmentEnd])
+		}
+
+		pos = segmentEnd
+	}
+
+	return 1
+}
+
+func parseTIFFOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifdOffset := int(order.Uint32(tiff[4:8]))
+	if ifdOffset+2 > len(tiff) {
+		return 1
+	}
+
+	entryCount := int(order.Uint16(tiff[ifdOffset : ifdOffset+2]))
+	for i := 0; i < entryCount; i++ {
+		entry := ifdOffset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:entry+2]) == 0x0112 {
+			orientation := int(order.Uint16(tiff[entry+8 : entry+10]))
+			if orientation < 1 || orientation > 8 {
+				return 1
+			}
+			return orientation
+		}
+	}
+
+	return 1
+}
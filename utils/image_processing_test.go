@@ -0,0 +1,218 @@
+// utils/image_processing_test.go
+
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func solidImage(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetRGBA(x, y, color.RGBA{R: 200, G: 80, B: 40, A: 255})
+		}
+	}
+	return img
+}
+
+func encodeTestJPEG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, solidImage(width, height), nil); err != nil {
+		t.Fatalf("jpeg.Encode: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func encodeTestPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, solidImage(width, height)); err != nil {
+		t.Fatalf("png.Encode: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// SOI 바로 뒤에 Orientation 태그 하나만 담은 APP1(Exif) 세그먼트를 끼워 넣음
+func withEXIFOrientation(data []byte, order binary.ByteOrder, orientation uint16) []byte {
+	tiff := make([]byte, 26)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], 8)
+	order.PutUint16(tiff[8:], 1)
+	order.PutUint16(tiff[10:], 0x0112)
+	order.PutUint16(tiff[12:], 3)
+	order.PutUint32(tiff[14:], 1)
+	order.PutUint16(tiff[18:], orientation)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, segment...)
+	return append(out, data[2:]...)
+}
+
+// IHDR의 가로/세로만 바꾼 PNG (DecodeConfig는 헤더만 읽으므로 픽셀 제한 검사에 사용)
+func withPNGSize(t *testing.T, data []byte, width, height uint32) []byte {
+	t.Helper()
+	// 8바이트 시그니처 뒤 IHDR: 길이(4) + 타입(4) + 데이터(13) + CRC(4)
+	if string(data[12:16]) != "IHDR" {
+		t.Fatalf("unexpected PNG chunk %q", data[12:16])
+	}
+	out := append([]byte{}, data...)
+	binary.BigEndian.PutUint32(out[16:], width)
+	binary.BigEndian.PutUint32(out[20:], height)
+	binary.BigEndian.PutUint32(out[29:], crc32.ChecksumIEEE(out[12:29]))
+	return out
+}
+
+func TestReadJPEGOrientation(t *testing.T) {
+	plain := encodeTestJPEG(t, 4, 2)
+
+	tests := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{"EXIF 없음", plain, 1},
+		{"리틀 엔디언 TIFF", withEXIFOrientation(plain, binary.LittleEndian, 6), 6},
+		{"빅 엔디언 TIFF", withEXIFOrientation(plain, binary.BigEndian, 3), 3},
+		{"범위를 벗어난 값", withEXIFOrientation(plain, binary.LittleEndian, 9), 1},
+		{"JPEG가 아님", encodeTestPNG(t, 4, 2), 1},
+		{"잘린 세그먼트", withEXIFOrientation(plain, binary.LittleEndian, 6)[:10], 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := readJPEGOrientation(tt.data); got != tt.want {
+				t.Errorf("readJPEGOrientation = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyOrientation(t *testing.T) {
+	// 3x2 이미지의 왼쪽 위 픽셀만 표시해 두고 회전/반전 후 위치를 확인
+	marked := color.RGBA{R: 255, A: 255}
+	src := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	src.SetRGBA(0, 0, marked)
+
+	tests := []struct {
+		orientation   int
+		width, height int
+		markX, markY  int
+	}{
+		{1, 3, 2, 0, 0},
+		{2, 3, 2, 2, 0},
+		{3, 3, 2, 2, 1},
+		{4, 3, 2, 0, 1},
+		{5, 2, 3, 0, 0},
+		{6, 2, 3, 1, 0},
+		{7, 2, 3, 1, 2},
+		{8, 2, 3, 0, 2},
+	}
+
+	for _, tt := range tests {
+		dst := applyOrientation(src, tt.orientation)
+		if dst.Bounds().Dx() != tt.width || dst.Bounds().Dy() != tt.height {
+			t.Errorf("orientation %d: size = %v, want %dx%d", tt.orientation, dst.Bounds().Size(), tt.width, tt.height)
+			continue
+		}
+		if got := dst.RGBAAt(tt.markX, tt.markY); got != marked {
+			t.Errorf("orientation %d: pixel (%d,%d) = %v, want the marked pixel", tt.orientation, tt.markX, tt.markY, got)
+		}
+	}
+}
+
+func TestProcessImageVariants(t *testing.T) {
+	tests := []struct {
+		name                        string
+		data                        []byte
+		original, medium, thumbnail image.Point
+	}{
+		{"작은 이미지는 확대하지 않음", encodeTestJPEG(t, 200, 100), image.Pt(200, 100), image.Pt(200, 100), image.Pt(200, 100)},
+		{"긴 변 기준 축소", encodeTestJPEG(t, 3000, 1500), image.Pt(2048, 1024), image.Pt(1080, 540), image.Pt(320, 160)},
+		{"PNG도 JPEG로 재인코딩", encodeTestPNG(t, 1500, 3000), image.Pt(1024, 2048), image.Pt(540, 1080), image.Pt(160, 320)},
+		{"EXIF 방향을 픽셀에 반영", withEXIFOrientation(encodeTestJPEG(t, 400, 200), binary.LittleEndian, 6), image.Pt(200, 400), image.Pt(200, 400), image.Pt(160, 320)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processed, err := ProcessImage(bytes.NewReader(tt.data))
+			if err != nil {
+				t.Fatalf("ProcessImage: %v", err)
+			}
+
+			variants := []struct {
+				name string
+				data []byte
+				want image.Point
+			}{
+				{"original", processed.Original, tt.original},
+				{"medium", processed.Medium, tt.medium},
+				{"thumbnail", processed.Thumbnail, tt.thumbnail},
+			}
+			for _, variant := range variants {
+				cfg, format, err := image.DecodeConfig(bytes.NewReader(variant.data))
+				if err != nil {
+					t.Fatalf("%s: DecodeConfig: %v", variant.name, err)
+				}
+				if format != "jpeg" {
+					t.Errorf("%s: format = %q, want jpeg", variant.name, format)
+				}
+				if got := image.Pt(cfg.Width, cfg.Height); got != variant.want {
+					t.Errorf("%s: size = %v, want %v", variant.name, got, variant.want)
+				}
+				// 재인코딩 결과에는 EXIF가 남지 않아야 함
+				if readJPEGOrientation(variant.data) != 1 || bytes.Contains(variant.data, []byte("Exif\x00\x00")) {
+					t.Errorf("%s: EXIF metadata was not stripped", variant.name)
+				}
+			}
+		})
+	}
+}
+
+func TestProcessImageRejects(t *testing.T) {
+	oversized := make([]byte, MaxImageUploadSize+1)
+	copy(oversized, encodeTestJPEG(t, 8, 8))
+
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"업로드 크기 초과", oversized, ErrImageTooLarge},
+		{"픽셀 수 초과", withPNGSize(t, encodeTestPNG(t, 8, 8), 10000, 5000), ErrImageTooLarge},
+		{"지원하지 않는 형식", []byte("GIF89a\x01\x00\x01\x00\x00\x00\x00;"), ErrUnsupportedImageType},
+		{"이미지가 아닌 데이터", []byte("hello, world"), ErrUnsupportedImageType},
+		{"깨진 JPEG", []byte("\xFF\xD8\xFFnot really a jpeg"), ErrInvalidImage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ProcessImage(bytes.NewReader(tt.data))
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("ProcessImage error = %v, want %v", err, tt.want)
+			}
+			if !IsImageValidationError(err) {
+				t.Errorf("IsImageValidationError(%v) = false, want true", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// utils/keyboard_layout.go
+
+package utils
+
+import (
+	"strings"
+)
+
+// 한/영 전환을 잊고 두벌식 자판으로 친 영문 입력("rlacl")을 한글("김치")로 되돌림
+
+var qwertyToJamo = map[rune]rune{
+	'q': 'ㅂ', 'w': 'ㅈ', 'e': 'ㄷ', 'r': 'ㄱ', 't': 'ㅅ', 'y': 'ㅛ', 'u': 'ㅕ', 'i': 'ㅑ', 'o': 'ㅐ', 'p': 'ㅔ',
+	'a': 'ㅁ', 's': 'ㄴ', 'd': 'ㅇ', 'f': 'ㄹ', 'g': 'ㅎ', 'h': 'ㅗ', 'j': 'ㅓ', 'k': 'ㅏ', 'l': 'ㅣ',
+	'z': 'ㅋ', 'x': 'ㅌ', 'c': 'ㅊ', 'v': 'ㅍ', 'b': 'ㅠ', 'n': 'ㅜ', 'm': 'ㅡ',
+	'Q': 'ㅃ', 'W': 'ㅉ', 'E': 'ㄸ', 'R': 'ㄲ', 'T': 'ㅆ', 'O': 'ㅒ', 'P': 'ㅖ',
+}
+
+var compoundVowels = map[[2]rune]rune{
+	{'ㅗ', 'ㅏ'}: 'ㅘ', {'ㅗ', 'ㅐ'}: 'ㅙ', {'ㅗ', 'ㅣ'}: 'ㅚ',
+	{'ㅜ', 'ㅓ'}: 'ㅝ', {'ㅜ', 'ㅔ'}: 'ㅞ', {'ㅜ', 'ㅣ'}: 'ㅟ',
+	{'ㅡ', 'ㅣ'}: 'ㅢ',
+}
+
+var compoundFinals = map[[2]rune]rune{
+	{'ㄱ', 'ㅅ'}: 'ㄳ', {'ㄴ', 'ㅈ'}: 'ㄵ', {'ㄴ', 'ㅎ'}: 'ㄶ',
+	{'ㄹ', 'ㄱ'}: 'ㄺ', {'ㄹ', 'ㅁ'}: 'ㄻ', {'ㄹ', 'ㅂ'}: 'ㄼ', {'ㄹ', 'ㅅ'}: 'ㄽ',
+	{'ㄹ', 'ㅌ'}: 'ㄾ', {'ㄹ', 'ㅍ'}: 'ㄿ', {'ㄹ', 'ㅎ'}: 'ㅀ',
+	{'ㅂ', 'ㅅ'}: 'ㅄ',
+}
+
+func jamoIndex(table []rune, ch rune) int {
+	for i, jamo := range table {
+		if jamo == ch && jamo != 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+func isVowelJamo(ch rune) bool {
+	return jamoIndex(VTable, ch) >= 0
+}
+
+// 두벌식 입력기처럼 자모를 하나씩 받아 음절을 조합하는 오토마타
+// 초성+중성이 갖춰지지 않은 채 남는 자모가 있으면 complete가 false가 됨
+type hangulComposer struct {
+	out      []rune
+	l, v, t  rune
+	complete bool
+}
+
+func (c *hangulComposer) flush() {
+	switch {
+	case c.l != 0 && c.v != 0:
+		l := jamoIndex(LTable, c.l)
+		v := jamoIndex(VTable, c.v)
+		t := 0
+		if c.t != 0 {
+			t = jamoIndex(TTable, c.t)
+		}
+		c.out = append(c.out, rune(SBase+(l*VCount+v)*TCount+t))
+	case c.l != 0:
+		c.out = append(c.out, c.l)
+		c.complete = false
+	case c.v != 0:
+		c.out = append(c.out, c.v)
+		c.complete = false
+	}
+	c.l, c.v, c.t = 0, 0, 0
+}
+
+func (c *hangulComposer) consonant(ch rune) {
+	if c.l != 0 && c.v != 0 {
+		if c.t == 0 && jamoIndex(TTable, ch) > 0 {
+			c.t = ch
+			return
+		}
+		if compound, exists := compoundFinals[[2]rune{c.t, ch}]; exists {
+			c.t = compound
+			return
+		}
+	}
+	c.flush()
+	c.l = ch
+}
+
+func (c *hangulComposer) vowel(ch rune) {
+	switch {
+	case c.t != 0:
+		// 받침 뒤에 모음이 오면 받침(겹받침이면 뒤쪽 자음)이 다음 글자의 초성이 됨
+		next := c.t
+		c.t = 0
+		if split, exists := typingJamoSplit[next]; exists {
+			c.t, next = split[0], split[1]
+		}
+		c.flush()
+		c.l, c.v = next, ch
+		return
+	case c.v != 0:
+		if compound, exists := compoundVowels[[2]rune{c.v, ch}]; exists {
+			c.v = compound
+			return
+		}
+	case c.l != 0:
+		c.v = ch
+		return
+	}
+	c.flush()
+	c.v = ch
+}
+
+// ConvertQwertyToHangul: 영문 자판 입력을 두벌식 한글로 변환
+// 영문자와 공백으로만 이루어져 있고, 변환 결과가 모두 완성된 음절일 때만 ok가 true임
+func ConvertQwertyToHangul(s string) (string, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", false
+	}
+	// 전부 대문자면 Shift가 아니라 Caps Lock이 켜진 것으로 봄
+	if strings.ToUpper(s) == s {
+		s = strings.ToLower(s)
+	}
+
+	composer := &hangulComposer{complete: true}
+	for _, ch := range s {
+		if ch == ' ' {
+			composer.flush()
+			composer.out = append(composer.out, ' ')
+			continue
+		}
+
+		// Shift로 쌍자음/ㅒ/ㅖ가 되는 키 외의 대문자는 소문자와 같은 자모
+		jamo, exists := qwertyToJamo[ch]
+		if !exists && ch >= 'A' && ch <= 'Z' {
+			jamo, exists = qwertyToJamo[ch-'A'+'a']
+		}
+		if !exists {
+			return "", false
+		}
+
+		if isVowelJamo(jamo) {
+			composer.vowel(jamo)
+		} else {
+			composer.consonant(jamo)
+		}
+	}
+	composer.flush()
+
+	if !composer.complete {
+		return "", false
+	}
+	return string(composer.out), true
+}
@@ -0,0 +1,48 @@
+// utils/keyboard_layout_test.go
+
+package utils
+
+import "testing"
+
+func TestConvertQwertyToHangul(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		want   string
+		wantOK bool
+	}{
+		{"Shift로 친 쌍자음", "EjrqhRdl", "떡볶이", true},
+		{"Shift 없이 친 쌍자음은 홑자음", "ejrqhrdl", "덕복이", true},
+		{"받침 없는 음절", "rlacl", "김치", true},
+		{"받침이 다음 글자 초성으로 넘어감", "qlqlaqkq", "비빔밥", true},
+		{"겹모음", "dnjsgks", "원한", true},
+		{"겹받침", "ekfr", "닭", true},
+		{"겹받침 뒤 모음", "ekfrdmf", "닭을", true},
+		{"띄어쓰기 유지", "rlacl Wlro", "김치 찌개", true},
+		{"Caps Lock은 소문자로 취급", "RLACL", "김치", true},
+		{"앞뒤 공백 제거", "  rlacl  ", "김치", true},
+		{"미완성 자모가 남음", "rlac", "", false},
+		{"영문자가 아닌 문자", "rlacl1", "", false},
+		{"빈 문자열", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ConvertQwertyToHangul(tt.input)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("ConvertQwertyToHangul(%q) = %q, %v; want %q, %v", tt.input, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestConvertedQwertyQueryMatchesFood(t *testing.T) {
+	// Shift를 빠뜨린 채 영문 자판으로 친 검색어도 변환 후 퍼지 매칭으로 찾을 수 있어야 함
+	converted, ok := ConvertQwertyToHangul("ejrqhrdl")
+	if !ok {
+		t.Fatalf("ConvertQwertyToHangul(%q) failed", "ejrqhrdl")
+	}
+	if score := SearchScore(converted, "떡볶이"); score == 0 {
+		t.Errorf("SearchScore(%q, 떡볶이) = 0, want a fuzzy match", converted)
+	}
+}
@@ -0,0 +1,73 @@
+// utils/meal_time.go
+
+package utils
+
+import (
+	"log"
+	"strings"
+	"time"
+)
+
+// 서비스 날짜(Day)와 끼니 시간대는 모두 한국 시간 기준
+var SeoulLoc *time.Location
+
+func init() {
+	var err error
+	SeoulLoc, err = time.LoadLocation("Asia/Seoul")
+	if err != nil {
+		log.Printf("WARNING: Failed to load Asia/Seoul location, using UTC: %v", err)
+		SeoulLoc = time.UTC
+	}
+}
+
+const (
+	MealTimeBreakfast = "breakfast"
+	MealTimeLunch     = "lunch"
+	MealTimeDinner    = "dinner"
+	MealTimeLateNight = "late-night"
+)
+
+var MealTimes = []string{MealTimeBreakfast, MealTimeLunch, MealTimeDinner, MealTimeLateNight}
+
+// 예전 리뷰의 meal_time은 클라이언트가 보낸 문자열 그대로 저장되어 있어 표기가 제각각이므로 끼니 하나로 모음
+// 새 리뷰는 저장할 때 CanonicalMealTime으로 바꾸며, 예전 리뷰는 admin backfill-meal-times로 바꿀 수 있음
+var mealTimeAliases = map[string]string{
+	"breakfast": MealTimeBreakfast, "morning": MealTimeBreakfast, "brunch": MealTimeBreakfast, "아침": MealTimeBreakfast,
+	"lunch": MealTimeLunch, "점심": MealTimeLunch,
+	"dinner": MealTimeDinner, "supper": MealTimeDinner, "evening": MealTimeDinner, "저녁": MealTimeDinner,
+	"latenight": MealTimeLateNight, "midnight": MealTimeLateNight, "night": MealTimeLateNight, "야식": MealTimeLateNight,
+}
+
+// NormalizeMealTime: "Late_Night", "야식" 등을 끼니 값으로 바꿈. 알 수 없는 값이면 ""
+func NormalizeMealTime(mealTime string) string {
+	key := strings.ToLower(strings.TrimSpace(mealTime))
+	key = strings.NewReplacer("-", "", "_", "", " ", "").Replace(key)
+	return mealTimeAliases[key]
+}
+
+// CanonicalMealTime: 끼니 값으로 바꿀 수 있으면 바꾸고, 알 수 없는 값("snack" 등)은 다듬기만 해서 그대로 둠
+// 리뷰의 meal_time은 자유 입력이므로 저장할 때는 이 값을 씀. 피드는 알 수 없는 값을 끼니가 없는 리뷰로 취급함
+func CanonicalMealTime(mealTime string) string {
+	if normalized := NormalizeMealTime(mealTime); normalized != "" {
+		return normalized
+	}
+	return strings.TrimSpace(mealTime)
+}
+
+// MealTimeAt: 한국 시간 기준으로 그 시각의 끼니
+// 05:00~10:30 아침, 10:30~16:00 점심, 16:00~21:30 저녁, 그 외 야식
+func MealTimeAt(t time.Time) string {
+	kst := t.In(SeoulLoc)
+	minutes := kst.Hour()*60 + kst.Minute()
+
+	switch {
+	case minutes >= 5*60 && minutes < 10*60+30:
+		return MealTimeBreakfast
+	case minutes >= 10*60+30 && minutes < 16*60:
+		return MealTimeLunch
+	case minutes >= 16*60 && minutes < 21*60+30:
+		return MealTimeDinner
+	default:
+		return MealTimeLateNight
+	}
+}
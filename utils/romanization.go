@@ -0,0 +1,172 @@
+// utils/romanization.go
+
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// 국어의 로마자 표기법(Revised Romanization) 변환
+// 음식 이름 매칭용이라 자음동화/연음/받침 대표음 정도만 반영하고, 격음화 같은 세부 규칙은 생략함
+
+var romanInitials = []string{"g", "kk", "n", "d", "tt", "r", "m", "b", "pp", "s", "ss", "", "j", "jj", "ch", "k", "t", "p", "h"}
+
+var romanVowels = []string{"a", "ae", "ya", "yae", "eo", "e", "yeo", "ye", "o", "wa", "wae", "oe", "yo", "u", "wo", "we", "wi", "yu", "eu", "ui", "i"}
+
+// 받침의 대표음 (TTable 순서)
+var romanFinals = []string{"", "k", "k", "k", "n", "n", "n", "t", "l", "k", "m", "l", "l", "l", "p", "l", "m", "p", "p", "t", "t", "ng", "t", "t", "k", "t", "p", "t"}
+
+// 다음 글자가 ㅇ으로 시작할 때 받침이 넘어가며 나는 소리 (TTable 순서)
+// 겹받침은 앞 자음은 받침으로 남고 뒤 자음만 넘어감
+var romanLiaison = []struct{ final, initial string }{
+	{"", ""}, {"", "g"}, {"", "kk"}, {"k", "s"}, {"", "n"}, {"n", "j"}, {"", "n"}, {"", "d"},
+	{"", "r"}, {"l", "g"}, {"l", "m"}, {"l", "b"}, {"l", "s"}, {"l", "t"}, {"l", "p"}, {"", "r"},
+	{"", "m"}, {"", "b"}, {"p", "s"}, {"", "s"}, {"", "ss"}, {"ng", ""}, {"", "j"}, {"", "ch"},
+	{"", "k"}, {"", "t"}, {"", "p"}, {"", ""},
+}
+
+type romanSyllable struct {
+	initial, vowel, final string
+	isHangul              bool
+	raw                   rune
+}
+
+// Romanize: 한글을 로마자로 변환 ("비빔밥" -> "bibimbap"), 한글이 아닌 문자는 그대로 둠
+func Romanize(s string) string {
+	syllables := make([]romanSyllable, 0, len(s))
+	for _, ch := range s {
+		if ch < SBase || ch >= SBase+SCount {
+			syllables = append(syllables, romanSyllable{raw: ch})
+			continue
+		}
+		index := int(ch - SBase)
+		syllables = append(syllables, romanSyllable{
+			initial:  romanInitials[index/NCount],
+			vowel:    romanVowels[(index%NCount)/TCount],
+			final:    romanFinals[index%TCount],
+			isHangul: true,
+			raw:      ch,
+		})
+	}
+
+	for i := 0; i+1 < len(syllables); i++ {
+		current, next := &syllables[i], &syllables[i+1]
+		if !current.isHangul || !next.isHangul {
+			continue
+		}
+		final := int(current.raw-SBase) % TCount
+
+		switch {
+		case next.initial == "" && final != 0:
+			// 연음: 받침이 다음 글자 초성으로 넘어감
+			current.final = romanLiaison[final].final
+			next.initial = romanLiaison[final].initial
+		case current.final == "l" && (next.initial == "r" || next.initial == "n"):
+			next.initial = "l"
+		case next.initial == "r" && (current.final == "n"):
+			current.final = "l"
+			next.initial = "l"
+		case next.initial == "r":
+			// ㄹ 앞의 ㅇ/ㅁ/ㄱ/ㅂ 받침 뒤에서는 ㄹ이 ㄴ으로 소리남
+			next.initial = "n"
+			fallthrough
+		case next.initial == "n" || next.initial == "m":
+			// 비음화: ㄱ/ㄷ/ㅂ 계열 받침이 ㄴ/ㅁ 앞에서 ㅇ/ㄴ/ㅁ으로 바뀜
+			switch current.final {
+			case "k":
+				current.final = "ng"
+			case "t":
+				current.final = "n"
+			case "p":
+				current.final = "m"
+			}
+		}
+	}
+
+	var out strings.Builder
+	for i, syllable := range syllables {
+		if !syllable.isHangul {
+			out.WriteRune(syllable.raw)
+			continue
+		}
+		// 첫 글자의 ㄹ은 r로 적음 (라면 -> ramyeon)
+		initial := syllable.initial
+		if i == 0 && initial == "l" {
+			initial = "r"
+		}
+		out.WriteString(initial)
+		out.WriteString(syllable.vowel)
+		out.WriteString(syllable.final)
+	}
+	return out.String()
+}
+
+// ContainsLatin: 영문자가 하나라도 있는지 여부
+func ContainsLatin(s string) bool {
+	for _, ch := range s {
+		if ch < unicode.MaxASCII && unicode.IsLetter(ch) {
+			return true
+		}
+	}
+	return false
+}
+
+// 로마자 표기는 사람마다 k/g, t/d, p/b, r/l을 섞어 쓰고 (kimchi/gimchi, pulgogi/bulgogi)
+// 쌍자음도 하나만 쓰는 경우가 많으므로 (jigae/jjigae) 이런 차이를 없앤 비교용 키
+var romanizedKeyReplacer = strings.NewReplacer("k", "g", "t", "d", "p", "b", "r", "l")
+
+// RomanizedKey: 한글은 로마자로 바꾼 뒤 영문자만 남기고 표기 차이를 정규화
+func RomanizedKey(s string) string {
+	romanized := strings.ToLower(Romanize(s))
+
+	var letters strings.Builder
+	for _, ch := range romanized {
+		if ch >= 'a' && ch <= 'z' {
+			letters.WriteRune(ch)
+		}
+	}
+	key := romanizedKeyReplacer.Replace(letters.String())
+
+	var out []rune
+	for _, ch := range key {
+		if len(out) > 0 && out[len(out)-1] == ch {
+			continue
+		}
+		out = append(out, ch)
+	}
+	return string(out)
+}
+
+// RomanizedScore: 영문 입력과 음식 이름(한글이면 로마자 표기)의 유사도. 영문 입력이 아니면 0
+func RomanizedScore(query, target string) float64 {
+	if !ContainsLatin(query) {
+		return 0
+	}
+	q, t := RomanizedKey(query), RomanizedKey(target)
+	if q == "" || t == "" {
+		return 0
+	}
+	return JaroWinkler(q, t)
+}
+
+// RomanizedSearchScore: 영문 검색어를 로마자 표기 기준으로 검색 점수를 매김. 영문 검색어가 아니면 0
+func RomanizedSearchScore(query, target string) float64 {
+	if !ContainsLatin(query) {
+		return 0
+	}
+	q, t := RomanizedKey(query), RomanizedKey(target)
+	if q == "" || t == "" {
+		return 0
+	}
+	return SearchScore(q, t)
+}
+
+// RomanizedEqual: 로마자 표기 기준으로 같은 이름인지 여부 ("bibimbap" == "비빔밥")
+func RomanizedEqual(query, target string) bool {
+	if !ContainsLatin(query) {
+		return false
+	}
+	q := RomanizedKey(query)
+	return q != "" && q == RomanizedKey(target)
+}
@@ -0,0 +1,81 @@
+// utils/romanization_test.go
+
+package utils
+
+import "testing"
+
+func TestRomanize(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"떡볶이", "tteokbokki"},
+		{"비빔밥", "bibimbap"},
+		{"라면", "ramyeon"},
+		{"김치찌개", "gimchijjigae"},
+		{"불고기", "bulgogi"},
+		{"신라면", "sillamyeon"},
+		{"국물", "gungmul"},
+		{"닭갈비", "dakgalbi"},
+		{"BBQ치킨", "BBQchikin"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Romanize(tt.input); got != tt.want {
+			t.Errorf("Romanize(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestRomanizedEqual(t *testing.T) {
+	tests := []struct {
+		query  string
+		target string
+		want   bool
+	}{
+		{"tteokbokki", "떡볶이", true},
+		{"Tteok Bokki", "떡볶이", true},
+		{"ddeokbokki", "떡볶이", true},
+		{"kimchi jigae", "김치찌개", true},
+		{"pulgogi", "불고기", true},
+		{"bibimbap", "비빔밥", true},
+		{"bibimbap", "떡볶이", false},
+		{"떡볶이", "떡볶이", false},
+		{"123", "떡볶이", false},
+	}
+
+	for _, tt := range tests {
+		if got := RomanizedEqual(tt.query, tt.target); got != tt.want {
+			t.Errorf("RomanizedEqual(%q, %q) = %v, want %v", tt.query, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestRomanizedSearchScore(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		target    string
+		wantMatch bool
+	}{
+		{"로마자 표기 일치", "tteokbokki", "떡볶이", true},
+		{"로마자 표기 앞부분", "tteok", "떡볶이", true},
+		{"로마자 철자 오타", "tteokbokii", "떡볶이", true},
+		{"다른 음식", "bibimbap", "떡볶이", false},
+		{"한글 검색어는 대상 아님", "떡볶이", "떡볶이", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := RomanizedSearchScore(tt.query, tt.target)
+			if (score > 0) != tt.wantMatch {
+				t.Errorf("RomanizedSearchScore(%q, %q) = %v, want match %v", tt.query, tt.target, score, tt.wantMatch)
+			}
+		})
+	}
+
+	if exact, prefix := RomanizedSearchScore("tteokbokki", "떡볶이"), RomanizedSearchScore("tteok", "떡볶이"); exact <= prefix {
+		t.Errorf("exact score %v <= prefix score %v, want exact to rank higher", exact, prefix)
+	}
+}